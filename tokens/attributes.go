@@ -0,0 +1,94 @@
+package tokens
+
+import (
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// ATTRIBUTES
+///
+// the parameters of a token are kept as a slice of pairs. Since there are
+// only a hand full of them per token, lookup is done by comparing keys, which
+// keeps them in the order they got passed to the callback. All methods that
+// alter the attributes return a new instance, the receiver stays unchanged.
+type Attributes []t.Pair
+
+func (a Attributes) Type() t.ValueType { return t.MAP }
+func (a Attributes) Eval() t.Evaluable { return a }
+func (a Attributes) Serialize() []byte {
+	var r = []byte{}
+	for _, p := range a {
+		r = append(r, p.Serialize()...)
+	}
+	return r
+}
+func (a Attributes) String() string     { return string(a.Serialize()) }
+func (a Attributes) Clear() t.Collected { return Attributes{} }
+func (a Attributes) Empty() bool        { return len(a) == 0 }
+func (a Attributes) Size() int          { return len(a) }
+func (a Attributes) Keys() []t.Evaluable {
+	var r = []t.Evaluable{}
+	for _, p := range a {
+		r = append(r, p.Key())
+	}
+	return r
+}
+func (a Attributes) Values() []t.Evaluable {
+	var r = []t.Evaluable{}
+	for _, p := range a {
+		r = append(r, p.Value())
+	}
+	return r
+}
+func (a Attributes) Interfaces() []interface{} {
+	var r = []interface{}{}
+	for _, v := range a.Values() {
+		r = append(r, v)
+	}
+	return r
+}
+
+// index of the pair with a key serializing identical to the passed one, -1
+// if no such pair is contained.
+func (a Attributes) index(k t.Evaluable) int {
+	for i, p := range a {
+		if p.Key().String() == k.String() {
+			return i
+		}
+	}
+	return -1
+}
+func (a Attributes) Get(k t.Evaluable) (t.Evaluable, bool) {
+	if i := a.index(k); i >= 0 {
+		return a[i].Value(), true
+	}
+	return nil, false
+}
+func (a Attributes) Put(k, v t.Evaluable) t.Mapped {
+	var r = append(Attributes{}, a...)
+	var p = t.Value(k, v).(t.Pair)
+	if i := r.index(k); i >= 0 {
+		r[i] = p
+	} else {
+		r = append(r, p)
+	}
+	return r
+}
+
+// pairs passed to add get mapped by their key, all other values are ignored.
+func (a Attributes) Add(v ...t.Evaluable) t.Mapped {
+	var r t.Mapped = a
+	for _, v := range v {
+		if p, ok := v.(t.Pair); ok {
+			r = r.Put(p.Key(), p.Value())
+		}
+	}
+	return r
+}
+func (a Attributes) Remove(k t.Evaluable) t.Mapped {
+	var i = a.index(k)
+	if i < 0 {
+		return a
+	}
+	return append(append(Attributes{}, a[:i]...), a[i+1:]...)
+}
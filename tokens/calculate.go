@@ -0,0 +1,371 @@
+package tokens
+
+import (
+	"bytes"
+	"strings"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// CALCULATION
+///
+// a sheet evaluates the formulas of a table. Formulas are evaluated in order
+// of their dependencies, so that each formula is evaluated once, after all
+// formulas it references. Formulas that depend on themselves, directly or by
+// way of other formulas, evaluate to ErrCycle.
+type sheet struct {
+	table   t.LabeledTable
+	rows    []t.Evaluable
+	cols    []t.Evaluable
+	results map[cell]t.Evaluable
+}
+
+// position of a cell, rows numbered as in the sheet, columns from zero
+type cell struct{ row, col int }
+
+// Calculate evaluates the formulas of all tables contained in the tree rooted
+// at the passed node and replaces the content of each formula cell by its
+// result, so that writing the tree yields the computed document. The tables
+// are returned, with formulas replaced by their results.
+func Calculate(n *Node) []Table {
+	var r = []Table{}
+	for _, x := range Tables(n) {
+		var s = newSheet(x.LabeledTable)
+		s.calculate()
+		var body = 0
+		for _, row := range x.node.children {
+			if len(row.children) > 0 && row.children[0].kind == t.TOKEN_TABLE_HEADER_CELL {
+				continue
+			}
+			body++
+			for j, c := range row.children {
+				if v, ok := s.results[cell{body + 1, j}]; ok {
+					c.setText(v.Serialize())
+				}
+			}
+		}
+		r = append(r, s.apply(x))
+	}
+	return r
+}
+
+// Evaluate returns a version of the table, with all formulas replaced by
+// their results.
+func (x Table) Evaluate() Table {
+	var s = newSheet(x.LabeledTable)
+	s.calculate()
+	return s.apply(x)
+}
+
+// replaces the content of a node by plain text
+func (n *Node) setText(v []byte) {
+	n.val = t.NewBytes(v)
+	n.children = nil
+	n.adopt(&Node{
+		Token: Token{t.TOKEN_NORMAL_TEXT, t.NewBytes(v), nil},
+		flag:  nodeType(t.TOKEN_NORMAL_TEXT),
+	})
+}
+
+func newSheet(l t.LabeledTable) *sheet {
+	return &sheet{l, l.RowKeys(), l.ColumnKeys(), map[cell]t.Evaluable{}}
+}
+
+// copy of the table, holding the results in place of the formulas
+func (s *sheet) apply(x Table) Table {
+	var l = x.LabeledTable
+	for c, v := range s.results {
+		l, _ = l.Set(s.rows[c.row-2], s.cols[c.col], v)
+	}
+	return Table{l, x.align, x.node}
+}
+
+// parses all formulas and evaluates them in order of their dependencies
+func (s *sheet) calculate() {
+	var formulas = map[cell]formula{}
+	var cells = []cell{}
+	for i := range s.rows {
+		for j := range s.cols {
+			var c = cell{i + 2, j}
+			var src, ok = formulaSource(s.value(c))
+			if !ok {
+				continue
+			}
+			cells = append(cells, c)
+			if f, ok := parseFormula(src); ok {
+				formulas[c] = f
+			} else {
+				formulas[c] = literal{ErrSyntax}
+			}
+		}
+	}
+	for _, component := range s.order(cells, formulas) {
+		var c = component[0]
+		if len(component) > 1 || s.dependsOn(formulas[c], c) {
+			for _, c := range component {
+				s.results[c] = ErrCycle
+			}
+			continue
+		}
+		s.results[c] = formulas[c].eval(s)
+	}
+}
+
+// order returns the strongly connected components of the dependency graph of
+// the formulas, so that each component follows all components it depends on.
+// Components of more than one cell are cycles. (Tarjan's algorithm)
+func (s *sheet) order(cells []cell, formulas map[cell]formula) [][]cell {
+	var r = [][]cell{}
+	var index, low = map[cell]int{}, map[cell]int{}
+	var stack = []cell{}
+	var onStack = map[cell]bool{}
+	var visit func(c cell)
+	visit = func(c cell) {
+		index[c], low[c] = len(index), len(index)
+		stack = append(stack, c)
+		onStack[c] = true
+		for _, d := range s.dependencies(formulas[c], formulas) {
+			if _, ok := index[d]; !ok {
+				visit(d)
+				low[c] = min(low[c], low[d])
+			} else if onStack[d] {
+				low[c] = min(low[c], index[d])
+			}
+		}
+		if low[c] != index[c] {
+			return
+		}
+		var component = []cell{}
+		for {
+			var d = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[d] = false
+			component = append(component, d)
+			if d == c {
+				break
+			}
+		}
+		r = append(r, component)
+	}
+	for _, c := range cells {
+		if _, ok := index[c]; !ok {
+			visit(c)
+		}
+	}
+	return r
+}
+
+// formula cells referenced by the formula
+func (s *sheet) dependencies(f formula, formulas map[cell]formula) []cell {
+	var r = []cell{}
+	for _, x := range f.refs() {
+		for _, c := range s.cells(x) {
+			if _, ok := formulas[c]; ok {
+				r = append(r, c)
+			}
+		}
+	}
+	return r
+}
+func (s *sheet) dependsOn(f formula, c cell) bool {
+	for _, x := range f.refs() {
+		for _, d := range s.cells(x) {
+			if d == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// value of the cell, the result, if it holds an evaluated formula. Row one
+// holds the keys of the columns. Cells outside of the table yield ErrRef.
+func (s *sheet) value(c cell) t.Evaluable {
+	if v, ok := s.results[c]; ok {
+		return v
+	}
+	switch {
+	case c.col < 0 || c.col >= len(s.cols) || c.row < 1 || c.row > len(s.rows)+1:
+		return ErrRef
+	case c.row == 1:
+		return s.cols[c.col]
+	}
+	return s.table.Element(s.rows[c.row-2], s.cols[c.col])
+}
+
+// cells of the range in row major order, clipped to the table
+func (s *sheet) cells(r cellRange) []cell {
+	if r.column {
+		r.row0, r.row1 = 2, len(s.rows)+1
+	}
+	var c = []cell{}
+	for i := max(r.row0, 1); i <= r.row1 && i <= len(s.rows)+1; i++ {
+		for j := max(r.col0, 0); j <= r.col1 && j < len(s.cols); j++ {
+			c = append(c, cell{i, j})
+		}
+	}
+	return c
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// EVALUATION
+///
+func (l literal) eval(s *sheet) t.Evaluable { return l.v }
+
+// references to single cells yield the value of the cell, empty cells count
+// as zero. Ranges can only be passed to functions.
+func (r reference) eval(s *sheet) t.Evaluable {
+	if !r.single() {
+		return ErrValue
+	}
+	var v = s.value(cell{r.row0, r.col0})
+	if v == nil || v.Type() == t.EMPTY {
+		return t.NewInteger(0)
+	}
+	return v
+}
+func (n negation) eval(s *sheet) t.Evaluable {
+	return arith('*', t.NewInteger(-1), n.x.eval(s))
+}
+func (o operation) eval(s *sheet) t.Evaluable {
+	return arith(o.op, o.x.eval(s), o.y.eval(s))
+}
+
+// functions get passed the values of all their arguments, ranges flattened.
+// Errors of any argument are returned as result.
+func (c call) eval(s *sheet) t.Evaluable {
+	var v = []t.Evaluable{}
+	for _, a := range c.args {
+		if r, ok := a.(reference); ok && !r.single() {
+			for _, x := range s.cells(r.cellRange) {
+				v = append(v, s.value(x))
+			}
+			continue
+		}
+		v = append(v, a.eval(s))
+	}
+	var nums = []t.Evaluable{}
+	for _, x := range v {
+		if e, ok := x.(CellError); ok {
+			return e
+		}
+		if x != nil && x.Type()&t.NUMERIC != 0 {
+			nums = append(nums, x)
+		}
+	}
+	switch c.name {
+	case "SUM":
+		return fold('+', t.NewInteger(0), nums)
+	case "PRODUCT":
+		return fold('*', t.NewInteger(1), nums)
+	case "AVG", "AVERAGE":
+		if len(nums) == 0 {
+			return ErrDivZero
+		}
+		return arith('/', fold('+', t.NewInteger(0), nums), t.NewInteger(int64(len(nums))))
+	case "MIN", "MAX":
+		if len(nums) == 0 {
+			return t.NewInteger(0)
+		}
+		var r, sign = nums[0], 1
+		if c.name == "MIN" {
+			sign = -1
+		}
+		for _, x := range nums[1:] {
+			if compare(x, r) == sign {
+				r = x
+			}
+		}
+		return r
+	case "COUNT":
+		return t.NewInteger(int64(len(nums)))
+	}
+	return ErrName
+}
+
+// applies the operator to all values, starting with the passed one
+func fold(op byte, r t.Evaluable, v []t.Evaluable) t.Evaluable {
+	for _, x := range v {
+		r = arith(op, r, x)
+	}
+	return r
+}
+
+// exact arithmetic on numbers, errors of the operands propagate
+func arith(op byte, x, y t.Evaluable) t.Evaluable {
+	for _, v := range []t.Evaluable{x, y} {
+		if e, ok := v.(CellError); ok {
+			return e
+		}
+		if v == nil || v.Type()&t.NUMERIC == 0 {
+			return ErrValue
+		}
+	}
+	var r t.Evaluable
+	var err error
+	switch op {
+	case '+':
+		r, err = t.Add(x, y)
+	case '-':
+		r, err = t.Sub(x, y)
+	case '*':
+		r, err = t.Mul(x, y)
+	case '/':
+		if compare(y, t.NewInteger(0)) == 0 {
+			return ErrDivZero
+		}
+		r, err = t.Quo(x, y)
+	}
+	if err != nil {
+		return ErrValue
+	}
+	return r
+}
+
+// numeric comparison of two numbers
+func compare(x, y t.Evaluable) int {
+	var c, err = t.Cmp(x, y)
+	if err != nil {
+		return 0
+	}
+	return int(c.(t.Integer).Int64())
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// FORMULA SOURCE
+///
+// formulas are text cells starting with '=', the source is returned without
+// it.
+func formulaSource(v t.Evaluable) (string, bool) {
+	if v == nil || v.Type() != t.TEXT {
+		return "", false
+	}
+	var s = strings.TrimSpace(v.String())
+	if !strings.HasPrefix(s, "=") {
+		return "", false
+	}
+	return s[1:], true
+}
+
+// plain text of a table cell. Formulas are taken from the markdown of the
+// cell instead, since operators like '*' might have been parsed as emphasis.
+func cellText(c *Node) []byte {
+	var v = c.val.Serialize()
+	if !bytes.HasPrefix(bytes.TrimSpace(v), []byte("=")) {
+		return v
+	}
+	return unescape(inline(c.children...))
+}
+
+// removes the backslashes escaping punctuation
+func unescape(text []byte) []byte {
+	var r = []byte{}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && bytes.IndexByte([]byte("\\`*_{}[]()#+-.!=:%<>~|"), text[i+1]) >= 0 {
+			i++
+		}
+		r = append(r, text[i])
+	}
+	return r
+}
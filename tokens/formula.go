@@ -0,0 +1,271 @@
+package tokens
+
+import (
+	"strings"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// FORMULAS
+///
+// cells of a table starting with '=' hold a formula. Formulas consist of
+// numbers, references, the operators + - * / with the usual precedence,
+// parentheses and calls of the functions SUM, AVG (or AVERAGE), MIN, MAX,
+// COUNT and PRODUCT. Cells are referenced spreadsheet style, columns by
+// letters, rows by number. Row one is the header of the table, so that the
+// body starts at row two:
+//
+//  =C2*D2		product of two cells
+//  =SUM(C2:C9)	sum of a range of cells
+//  =AVG(B)	average of all cells of the body of column B
+//  =SUM(A2:B3, 1)	arguments get seperated by commas, or semicolons
+//
+// All arithmetic is exact, results are Integer, if they are integral, Ratio
+// otherwise. Functions skip empty cells and text contained in ranges. Empty
+// cells referenced directly count as zero. Ranges containing the cell of the
+// formula itself, like a column summed up in its own body, form a cycle.
+
+// CellError is the value of a cell, whose formula failed. Errors of cells
+// referenced by a formula propagate to its result. Errors are typed ERROR, so
+// that they never equal text of the same content.
+type CellError string
+
+const (
+	ErrDivZero CellError = "#DIV/0!" // division by zero
+	ErrRef     CellError = "#REF!"   // reference to a cell outside of the table
+	ErrName    CellError = "#NAME?"  // unknown function
+	ErrValue   CellError = "#VALUE!" // operand of the wrong type
+	ErrCycle   CellError = "#CYCLE!" // formula depends on itself
+	ErrSyntax  CellError = "#ERROR!" // formula can not be parsed
+)
+
+func (e CellError) Type() t.ValueType { return t.ERROR }
+func (e CellError) Eval() t.Evaluable { return e }
+func (e CellError) Serialize() []byte { return []byte(e) }
+func (e CellError) String() string    { return string(e) }
+func (e CellError) Error() string     { return "tokens: cell evaluates to " + string(e) }
+
+// a formula evaluates to a value within a sheet and references ranges of
+// cells of that sheet.
+type formula interface {
+	eval(s *sheet) t.Evaluable
+	refs() []cellRange
+}
+
+type (
+	literal   struct{ v t.Evaluable }
+	reference struct{ cellRange }
+	negation  struct{ x formula }
+	operation struct {
+		op   byte
+		x, y formula
+	}
+	call struct {
+		name string
+		args []formula
+	}
+)
+
+// rows are numbered as in the sheet, columns counted from zero. The range of
+// a whole column spans all rows of the body.
+type cellRange struct {
+	row0, col0, row1, col1 int
+	column                 bool
+}
+
+func (r cellRange) single() bool {
+	return !r.column && r.row0 == r.row1 && r.col0 == r.col1
+}
+
+func (l literal) refs() []cellRange   { return nil }
+func (r reference) refs() []cellRange { return []cellRange{r.cellRange} }
+func (n negation) refs() []cellRange  { return n.x.refs() }
+func (o operation) refs() []cellRange { return append(o.x.refs(), o.y.refs()...) }
+func (c call) refs() []cellRange {
+	var r = []cellRange{}
+	for _, a := range c.args {
+		r = append(r, a.refs()...)
+	}
+	return r
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// PARSER
+///
+// recursive descent, one function per level of precedence
+type parser struct {
+	src string
+	pos int
+}
+
+// parseFormula parses the formula following the leading '='
+func parseFormula(src string) (formula, bool) {
+	var p = &parser{src: src}
+	var f, ok = p.expr()
+	p.space()
+	return f, ok && p.pos == len(p.src)
+}
+
+// skips spaces and returns the next character, zero at the end
+func (p *parser) peek() byte {
+	p.space()
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+func (p *parser) space() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// reads the run of characters matching the passed function
+func (p *parser) run(match func(c byte) bool) string {
+	var from = p.pos
+	for p.pos < len(p.src) && match(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[from:p.pos]
+}
+
+// expr = term {('+'|'-') term}
+func (p *parser) expr() (formula, bool) {
+	var x, ok = p.term()
+	for ok && (p.peek() == '+' || p.peek() == '-') {
+		var o = operation{op: p.src[p.pos], x: x}
+		p.pos++
+		o.y, ok = p.term()
+		x = o
+	}
+	return x, ok
+}
+
+// term = unary {('*'|'/') unary}
+func (p *parser) term() (formula, bool) {
+	var x, ok = p.unary()
+	for ok && (p.peek() == '*' || p.peek() == '/') {
+		var o = operation{op: p.src[p.pos], x: x}
+		p.pos++
+		o.y, ok = p.unary()
+		x = o
+	}
+	return x, ok
+}
+
+// unary = ('-'|'+') unary | primary
+func (p *parser) unary() (formula, bool) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		var x, ok = p.unary()
+		return negation{x}, ok
+	case '+':
+		p.pos++
+		return p.unary()
+	}
+	return p.primary()
+}
+
+// primary = number | '(' expr ')' | name '(' [expr {(','|';') expr}] ')' | range
+func (p *parser) primary() (formula, bool) {
+	var c = p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		var x, ok = p.expr()
+		if !ok || p.peek() != ')' {
+			return nil, false
+		}
+		p.pos++
+		return x, true
+	case isDigit(c) || c == '.':
+		var v = CellValue([]byte(p.run(func(c byte) bool { return isDigit(c) || c == '.' })))
+		if v.Type()&t.NUMERIC == 0 {
+			return nil, false
+		}
+		return literal{v}, true
+	case isLetter(c):
+		var from = p.pos
+		var name = p.run(isLetter)
+		if p.peek() == '(' {
+			p.pos++
+			return p.call(strings.ToUpper(name))
+		}
+		p.pos = from
+		return p.cellRange()
+	}
+	return nil, false
+}
+
+// arguments of a function call, following the opening parenthesis
+func (p *parser) call(name string) (formula, bool) {
+	var c = call{name: name}
+	if p.peek() == ')' {
+		p.pos++
+		return c, true
+	}
+	for {
+		var x, ok = p.expr()
+		if !ok {
+			return nil, false
+		}
+		c.args = append(c.args, x)
+		switch p.peek() {
+		case ',', ';':
+			p.pos++
+		case ')':
+			p.pos++
+			return c, true
+		default:
+			return nil, false
+		}
+	}
+}
+
+// range = cell [':' cell] | column [':' column]
+func (p *parser) cellRange() (formula, bool) {
+	var r cellRange
+	var ok bool
+	if r.col0, r.row0, ok = p.cell(); !ok {
+		return nil, false
+	}
+	r.col1, r.row1, r.column = r.col0, r.row0, r.row0 < 0
+	if p.peek() == ':' {
+		p.pos++
+		if r.col1, r.row1, ok = p.cell(); !ok || (r.row1 < 0) != r.column {
+			return nil, false
+		}
+	}
+	if r.row0 > r.row1 {
+		r.row0, r.row1 = r.row1, r.row0
+	}
+	if r.col0 > r.col1 {
+		r.col0, r.col1 = r.col1, r.col0
+	}
+	return reference{r}, true
+}
+
+// column letters, followed by the row number. The row is -1, if it's
+// missing.
+func (p *parser) cell() (col, row int, ok bool) {
+	var letters = strings.ToUpper(p.run(isLetter))
+	if letters == "" {
+		return 0, 0, false
+	}
+	for _, c := range letters {
+		col = col*26 + int(c-'A') + 1
+	}
+	var digits = p.run(isDigit)
+	if digits == "" {
+		return col - 1, -1, true
+	}
+	for _, c := range digits {
+		row = row*10 + int(c-'0')
+	}
+	return col - 1, row, row > 0
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
@@ -0,0 +1,142 @@
+package tokens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+var formulaDoc = `| Item | Qty | Price | Total |
+|------|----:|------:|------:|
+| a    | 2   | 1.5   | =B2*C2 |
+| b    | 3   | 1/3   | =B3*C3*2 |
+| sum  | =SUM(B2:B3) |  | =SUM(D2:D3) |
+| avg  | =AVG(B2:B3) | =MAX(C2:C3) | =avg(d2:d3) / (B4 - 5) |
+`
+
+// cells of the evaluated table of the document, row by row
+func evaluated(doc string) []string { return evaluatedRows(Tables(Parse([]byte(doc), extensions))[0]) }
+func evaluatedRows(tab Table) []string {
+	var r = []string{}
+	var x = tab.Evaluate()
+	for _, k := range x.RowKeys() {
+		var row = []string{}
+		for _, c := range x.ColumnKeys() {
+			row = append(row, x.Element(k, c).String())
+		}
+		r = append(r, strings.Join(row, " "))
+	}
+	return r
+}
+
+var formulaTests = []struct {
+	doc string
+	exp []string
+}{
+	{formulaDoc, []string{"a 2 3/2 3", "b 3 1/3 2", "sum 5 EMPTY 5", "avg 5/2 3/2 #DIV/0!"}},
+	{ // precedence, unary minus and parentheses
+		"| A | B |\n|---|---|\n| 2 | =-A2+3*(A2-1)/4 |\n| 1 | = 2 * -(A3 + 0.5) |\n",
+		[]string{"2 -5/4", "1 -3"},
+	},
+	{ // formulas referencing formulas get evaluated after them
+		"| A | B |\n|---|---|\n| =B2+1 | =B3*2 |\n| =A2+A3 | 5 |\n",
+		[]string{"11 10", "#CYCLE! 5"},
+	},
+	{ // ranges containing their own cell are cycles as well
+		"| A | B |\n|---|---|\n| 1 | 2 |\n| =SUM(A) | =MAX(A2:B3) |\n",
+		[]string{"1 2", "#CYCLE! #CYCLE!"},
+	},
+	{ // cycles, and formulas depending on them
+		"| A | B | C |\n|---|---|---|\n| =B2 | =A2 | =A2+1 |\n| =A3 | =SUM(C) | =COUNT(A2:B3) |\n",
+		[]string{"#CYCLE! #CYCLE! #CYCLE!", "#CYCLE! #CYCLE! #CYCLE!"},
+	},
+	{ // error values
+		"| A | B |\n|---|---|\n| x | =A2+1 |\n| 0 | =1/A3 |\n| =Z9 | =FOO(1) |\n| =1+ | =A2:A3 |\n| =B3+1 | =SUM(A) |\n",
+		[]string{"x #VALUE!", "0 #DIV/0!", "#REF! #NAME?", "#ERROR! #VALUE!", "#DIV/0! #REF!"},
+	},
+	{ // functions skip text and empty cells of ranges, but count empty references as zero
+		"| A | B |\n|---|---|\n| 4 | =COUNT(A) |\n| x | =MIN(A2:A5) |\n|   | =A4*2+PRODUCT(A2:A5; 2) |\n| -1 | =SUM() |\n",
+		[]string{"4 2", "x -1", "EMPTY -8", "-1 0"},
+	},
+}
+
+func TestFormula(x *testing.T) {
+	for n, test := range formulaTests {
+		var got = strings.Join(evaluated(test.doc), "|")
+		if exp := strings.Join(test.exp, "|"); got != exp {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed Test Nr. %d got: %q expected: %q", n, got, exp))
+		}
+	}
+}
+
+var parseTests = []struct {
+	src string
+	ok  bool
+}{
+	{"1+2*3", true},
+	{"SUM(A2:B9; C, 3/4)", true},
+	{"-(-A1)", true},
+	{"sum()", true},
+	{"AA10:AB12", true},
+	{"1+", false},
+	{"(1", false},
+	{"A2:B", false},
+	{"A0", false},
+	{"SUM(1,)", false},
+	{"1.2.3", false},
+	{"2A", false},
+}
+
+func TestParseFormula(x *testing.T) {
+	for _, test := range parseTests {
+		if _, ok := parseFormula(test.src); ok != test.ok {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed parsing %q got: %v expected: %v", test.src, ok, test.ok))
+		}
+	}
+	if f, _ := parseFormula("ab12"); fmt.Sprint(f.refs()) != "[{12 27 12 27 false}]" {
+		x.Fail()
+		x.Log("failed: column letters not decoded: " + fmt.Sprint(f.refs()))
+	}
+}
+
+// errors are values of their own type, distinct from text of the same content
+func TestCellError(x *testing.T) {
+	if got := fmt.Sprint(ErrDivZero.Type(), t.Equal(ErrDivZero, t.NewText("#DIV/0!")), t.Equal(ErrDivZero, ErrDivZero)); got != "ERROR false true" {
+		x.Fail()
+		x.Log("failed: cell error got: " + got + " expected: ERROR false true")
+	}
+	var e = Tables(Parse([]byte("| A | B |\n|---|---|\n| 0 | =1/A2 |\n"), extensions))[0].Evaluate()
+	if got := string(e.Element(t.NewInteger(1), t.NewText("B")).Type().Serialize()); got != "ERROR" {
+		x.Fail()
+		x.Log("failed: type of error cell serialized got: " + got + " expected: ERROR")
+	}
+	if _, ok := formulaSource(CellError("=1")); ok {
+		x.Fail()
+		x.Log("failed: cell error taken as formula")
+	}
+}
+
+// the results get written back to the document, which keeps all other content
+func TestCalculate(x *testing.T) {
+	var root = Parse([]byte("# Costs\n\n"+formulaDoc), extensions)
+	var tables = Calculate(root)
+	if v := tables[0].Element(t.NewInteger(3), t.NewText("Total")); v.String() != "5" {
+		x.Fail()
+		x.Log("failed: returned table not evaluated: " + v.String())
+	}
+	var exp = "# Costs\n\n" +
+		"| Item | Qty | Price | Total |\n" +
+		"| --- | ---: | ---: | ---: |\n" +
+		"| a | 2 | 1.5 | 3 |\n" +
+		"| b | 3 | 1/3 | 2 |\n" +
+		"| sum | 5 |  | 5 |\n" +
+		"| avg | 5/2 | 3/2 | \\#DIV/0! |\n"
+	if got := string(Markdown(root)); got != exp {
+		x.Fail()
+		x.Log(fmt.Sprintf("failed writing: got: %q expected: %q", got, exp))
+	}
+}
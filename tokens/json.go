@@ -0,0 +1,189 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// JSON LINES
+///
+// tokens are encoded as one json object per line, containing the kind of the
+// token by name, its payload as string and its parameters as object, keyed by
+// parameter name:
+//
+//	{"kind":"TOKEN_HEADER","value":"Title","parms":{"level":1,"id":""}}
+//
+// Symbolic parameters are encoded as strings, all others as numbers. The
+// parameters are written in the order they got passed to the callback, which
+// is preserved when decoding.
+
+// names of all single token types, to look up the kind by name
+var kinds = func() map[string]t.TokenType {
+	var r = map[string]t.TokenType{t.TOKEN_NONE.String(): t.TOKEN_NONE}
+	for _, k := range t.TOKEN_MASK.Kinds() {
+		r[k.String()] = k
+	}
+	return r
+}()
+
+// MarshalJSON encodes the token as json object
+func (k Token) MarshalJSON() ([]byte, error) {
+	var buf = &bytes.Buffer{}
+	buf.WriteString(`{"kind":`)
+	writeJSON(buf, string(k.kind.Serialize()))
+	buf.WriteString(`,"value":`)
+	if k.val != nil {
+		writeJSON(buf, k.val.String())
+	} else {
+		buf.WriteString(`""`)
+	}
+	buf.WriteString(`,"parms":{`)
+	for i, p := range k.parm {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSON(buf, p.Key().String())
+		buf.WriteByte(':')
+		var v = p.Value()
+		if v.Type()&t.SYMBOLIC == 0 && isNumber(v.String()) {
+			buf.WriteString(v.String())
+		} else {
+			writeJSON(buf, v.String())
+		}
+	}
+	buf.WriteString("}}")
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a token from a json object, as written by MarshalJSON
+func (k *Token) UnmarshalJSON(data []byte) error {
+	var obj struct {
+		Kind  string          `json:"kind"`
+		Value string          `json:"value"`
+		Parms json.RawMessage `json:"parms"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	var kind = t.TOKEN_NONE
+	for _, name := range strings.Split(obj.Kind, "|") {
+		x, ok := kinds[name]
+		if !ok {
+			return fmt.Errorf("tokens: unknown token kind %q", name)
+		}
+		kind |= x
+	}
+	parm, err := decodeParms(obj.Parms)
+	if err != nil {
+		return err
+	}
+	*k = Token{kind, t.NewBytes([]byte(obj.Value)), parm}
+	return nil
+}
+
+// the parameters are decoded token by token, since unmarshaling to a map
+// would loose their order
+func decodeParms(data json.RawMessage) ([]t.Pair, error) {
+	var r = []t.Pair{}
+	if len(data) == 0 || string(data) == "null" {
+		return r, nil
+	}
+	var dec = json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("tokens: parameters are not an object: %s", data)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var name = tok.(string)
+		if tok, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		var p t.Pair
+		switch v := tok.(type) {
+		case json.Number:
+			i, err := strconv.Atoi(v.String())
+			if err != nil {
+				return nil, fmt.Errorf("tokens: parameter %s is no integer: %s", name, v)
+			}
+			p = parm(name, i)
+		case string:
+			p = parm(name, v)
+		default:
+			return nil, fmt.Errorf("tokens: parameter %s is neither string, nor number", name)
+		}
+		// numeric keys, like the column indices of tables, are restored
+		// as numbers
+		if i, err := strconv.Atoi(name); err == nil {
+			p = t.Value(i, p.Value()).(t.Pair)
+		}
+		r = append(r, p)
+	}
+	return r, nil
+}
+
+// writes a string as quoted json string
+func writeJSON(buf *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s)
+	buf.Write(b)
+}
+
+// determines if the string representation of a value is a valid json number
+func isNumber(s string) bool {
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) {
+		return false
+	}
+	var n json.Number
+	return json.Unmarshal([]byte(s), &n) == nil
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// ENCODER & DECODER
+///
+// Encoder writes tokens as json lines
+type Encoder struct{ w io.Writer }
+
+func NewEncoder(w io.Writer) *Encoder { return &Encoder{w} }
+
+// Encode writes the token as single line, terminated by a newline
+func (e *Encoder) Encode(k Token) error {
+	b, err := k.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+// EncodeAll writes all tokens received from the channel, until it gets
+// closed, or writing fails.
+func (e *Encoder) EncodeAll(tok <-chan Token) error {
+	for k := range tok {
+		if err := e.Encode(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decoder reads tokens from json lines
+type Decoder struct{ dec *json.Decoder }
+
+func NewDecoder(r io.Reader) *Decoder { return &Decoder{json.NewDecoder(r)} }
+
+// Decode reads the next token. At the end of the input io.EOF is returned.
+func (d *Decoder) Decode() (Token, error) {
+	var k Token
+	err := d.dec.Decode(&k)
+	return k, err
+}
@@ -0,0 +1,114 @@
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+// serialize the token, comparing kind, payload and all parameters
+func tokenString(k Token) string {
+	var s = k.Kind().String() + " " + fmt.Sprintf("%q", k.Value().String())
+	for _, p := range k.Parms() {
+		s = s + " " + p.Key().String() + "=" + p.Value().String()
+	}
+	return s
+}
+
+var jsonTests = []struct {
+	tok  Token
+	line string
+}{
+	{
+		Token{t.TOKEN_HEADER, t.NewBytes([]byte("Title")), []t.Pair{parm("level", 1), parm("id", "")}},
+		`{"kind":"TOKEN_HEADER","value":"Title","parms":{"level":1,"id":""}}`,
+	},
+	{
+		Token{t.TOKEN_NORMAL_TEXT, t.NewBytes([]byte("a \"quoted\"\n")), nil},
+		`{"kind":"TOKEN_NORMAL_TEXT","value":"a \"quoted\"\n","parms":{}}`,
+	},
+	{
+		Token{t.TOKEN_TABLE, t.NewBytes([]byte("ab")), []t.Pair{t.Value(0, 1).(t.Pair), t.Value(1, 0).(t.Pair)}},
+		`{"kind":"TOKEN_TABLE","value":"ab","parms":{"0":1,"1":0}}`,
+	},
+	{
+		Token{t.TOKEN_DOCUMENT, t.NewBytes(nil), nil},
+		`{"kind":"TOKEN_DOCUMENT_HEADER|TOKEN_DOCUMENT_FOOTER","value":"","parms":{}}`,
+	},
+}
+
+func TestTokenJSON(x *testing.T) {
+	for _, test := range jsonTests {
+		b, err := test.tok.MarshalJSON()
+		if err != nil || string(b) != test.line {
+			x.Fail()
+			x.Log("failed: got: " + string(b) + " expected: " + test.line)
+		}
+		var k Token
+		if err := k.UnmarshalJSON([]byte(test.line)); err != nil {
+			x.Fatal(err)
+		}
+		if k.Kind() != test.tok.Kind() || k.Value().String() != string(test.tok.Value().Serialize()) {
+			x.Fail()
+			x.Log("failed: decoded: " + tokenString(k) + " from: " + test.line)
+		}
+		if b, _ := k.MarshalJSON(); string(b) != test.line {
+			x.Fail()
+			x.Log("failed: re-encoded: " + string(b) + " expected: " + test.line)
+		}
+	}
+}
+
+// the token stream of a document survives encoding and decoding unchanged
+func TestEncodeDecode(x *testing.T) {
+	src, err := ioutil.ReadFile("testdata/blocks.md")
+	if err != nil {
+		x.Fatal(err)
+	}
+	var buf = &bytes.Buffer{}
+	var expect = []string{}
+	tok, errs := Tokenize(context.Background(), bytes.NewReader(src), extensions)
+	var enc = NewEncoder(buf)
+	for k := range tok {
+		expect = append(expect, tokenString(k))
+		if err := enc.Encode(k); err != nil {
+			x.Fatal(err)
+		}
+	}
+	if err := <-errs; err != nil {
+		x.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(expect) {
+		x.Fatalf("failed: %d lines written for %d tokens", n, len(expect))
+	}
+	var dec = NewDecoder(buf)
+	for i := 0; ; i++ {
+		k, err := dec.Decode()
+		if err == io.EOF {
+			if i != len(expect) {
+				x.Errorf("failed: decoded %d tokens, expected %d", i, len(expect))
+			}
+			break
+		}
+		if err != nil {
+			x.Fatal(err)
+		}
+		if got := tokenString(k); i >= len(expect) || got != expect[i] {
+			x.Fatalf("failed: token %d decoded as: %s", i, got)
+		}
+	}
+}
+
+func TestDecodeUnknownKind(x *testing.T) {
+	_, err := NewDecoder(strings.NewReader(`{"kind":"TOKEN_BOGUS","value":"","parms":{}}`)).Decode()
+	if err == nil {
+		x.Fail()
+		x.Log("failed: unknown kind decoded without error")
+	}
+}
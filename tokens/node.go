@@ -0,0 +1,187 @@
+package tokens
+
+import (
+	"bytes"
+	t "github.com/JoergReinhardt/blackfriday/types"
+	b "github.com/russross/blackfriday"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// NODE
+///
+// a node wraps a token, to place it within the document tree. The payload of
+// a node contains the plain text of all its children.
+type Node struct {
+	Token
+	flag     t.NodeType
+	parent   *Node
+	children []*Node
+}
+
+func (n *Node) Flag() t.NodeType { return n.flag }
+func (n *Node) Parent() *Node    { return n.parent }
+
+// children get returned as a list of nodes, in the order of their appearance
+// in the document
+func (n *Node) Children() t.Listed {
+	var v = []t.Evaluable{}
+	for _, c := range n.children {
+		v = append(v, c)
+	}
+	return t.NewArrayList(v...)
+}
+
+// parameters passed to the callback that emitted the token, mapped on to
+// their names.
+func (n *Node) Attributes() t.Mapped { return Attributes(n.parm) }
+
+// nodes implement evaluable, so that they can be stored in collections. The
+// children are accessed by Children, the node itself is no collection.
+func (n *Node) Type() t.ValueType { return t.NODE }
+func (n *Node) Eval() t.Evaluable { return n }
+func (n *Node) Serialize() []byte { return n.val.Serialize() }
+func (n *Node) String() string    { return n.val.String() }
+func (n *Node) adopt(c ...*Node) {
+	for _, c := range c {
+		c.parent = n
+		n.children = append(n.children, c)
+	}
+	if len(n.children) > 0 {
+		n.flag &^= t.NODE_LEAF
+	}
+}
+
+// Walk traverses the tree depth first. The passed function gets called twice
+// per node, once with NODE_OPEN, when the node is entered, and once with
+// NODE_CLOSE, after all its children have been walked. If the function
+// returns false, the walk is canceled and Walk returns false.
+func (n *Node) Walk(fn func(event t.NodeType, n *Node) bool) bool {
+	if !fn(t.NODE_OPEN, n) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.Walk(fn) {
+			return false
+		}
+	}
+	return fn(t.NODE_CLOSE, n)
+}
+
+// node flag derived from the token type
+func nodeType(k t.TokenType) (r t.NodeType) {
+	switch {
+	case k&t.TOKEN_DOCUMENT != 0:
+		r = t.NODE_ROOT
+	case k&t.TOKEN_BLOCK != 0:
+		r = t.NODE_BLOCK
+	default:
+		r = t.NODE_SPAN
+	}
+	return r | t.NODE_LEAF
+}
+
+/////////////////////////////////////////////////////////////////////////
+//// TREE
+///
+// the tree gets assembled while the renderer callbacks are called. Since
+// blackfriday calls the callbacks of nested elements before those of the
+// elements enclosing them, nodes are kept pending, until their parent comes
+// along. Pending nodes are kept per output buffer they got written to.
+type tree struct {
+	root    *Node
+	pending map[*bytes.Buffer][]*Node
+}
+
+func newTree() *tree {
+	return &tree{pending: map[*bytes.Buffer][]*Node{}}
+}
+
+// mark returns the number of nodes pending in the passed buffer
+func (r *tree) mark(out *bytes.Buffer) int { return len(r.pending[out]) }
+
+// reset discards all nodes pending in the buffer beyond the mark
+func (r *tree) reset(out *bytes.Buffer, from int) {
+	r.pending[out] = r.pending[out][:from]
+}
+
+// source looks up the buffer, the passed payload has been taken from
+func (r *tree) source(src []byte) *bytes.Buffer {
+	if len(src) == 0 {
+		return nil
+	}
+	for buf := range r.pending {
+		if b := buf.Bytes(); len(b) > 0 && &b[0] == &src[0] {
+			return buf
+		}
+	}
+	return nil
+}
+
+// add places the token in the tree. If from is not negative, all nodes pending
+// in the output buffer beyond that mark, are adopted as children. Nodes
+// pending in the buffers the source slices got taken from, are adopted
+// as well. The root is the node of the document header, empty text is
+// dropped.
+func (r *tree) add(out *bytes.Buffer, from int, k Token, src ...[]byte) {
+	switch k.kind {
+	case t.TOKEN_DOCUMENT_HEADER:
+		r.root = &Node{Token: k, flag: nodeType(k.kind)}
+		return
+	case t.TOKEN_DOCUMENT_FOOTER:
+		r.root.val = t.NewBytes(out.Bytes())
+		r.root.adopt(r.pending[out]...)
+		r.pending = map[*bytes.Buffer][]*Node{}
+		return
+	}
+	if k.kind == t.TOKEN_IMAGE || k.kind == t.TOKEN_LINE_BREAK {
+		r.trim(out, k.kind)
+	}
+	if k.kind == t.TOKEN_NORMAL_TEXT && len(k.val.Serialize()) == 0 {
+		return
+	}
+	var n = &Node{Token: k, flag: nodeType(k.kind)}
+	if from >= 0 {
+		n.adopt(r.pending[out][from:]...)
+		r.reset(out, from)
+	}
+	for _, s := range src {
+		if buf := r.source(s); buf != nil {
+			n.adopt(r.pending[buf]...)
+			delete(r.pending, buf)
+		}
+	}
+	r.pending[out] = append(r.pending[out], n)
+}
+
+// some characters have allready been passed on as plain text, when the
+// parser recognizes the element they belong to. The parser truncates them
+// from the output buffer, the text node gets trimmed accordingly. Images drop
+// the exclamation mark introducing them, line breaks the trailing spaces.
+func (r *tree) trim(out *bytes.Buffer, kind t.TokenType) {
+	var p = r.pending[out]
+	if len(p) == 0 || p[len(p)-1].kind != t.TOKEN_NORMAL_TEXT {
+		return
+	}
+	var n = p[len(p)-1]
+	var v = n.val.Serialize()
+	switch kind {
+	case t.TOKEN_IMAGE:
+		if len(v) > 0 && v[len(v)-1] == '!' {
+			v = v[:len(v)-1]
+		}
+	case t.TOKEN_LINE_BREAK:
+		v = bytes.TrimRight(v, " ")
+	}
+	if len(v) == 0 {
+		r.pending[out] = p[:len(p)-1]
+		return
+	}
+	n.val = t.NewBytes(v)
+}
+
+// Parse renders the markdown document and returns the root node of its tree
+func Parse(input []byte, extensions int) *Node {
+	var r = newTree()
+	b.Markdown(input, Renderer{tree: r}, extensions)
+	return r.root
+}
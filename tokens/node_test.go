@@ -0,0 +1,169 @@
+package tokens
+
+import (
+	"fmt"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+var nodeDoc = "# Title\n\nsome *em* text\n\n- one\n- two\n"
+
+// nodes in the order they are opened, by depth, kind and the text of text
+// nodes
+var nodeTreeTests = []struct {
+	depth int
+	kind  t.TokenType
+	text  string
+}{
+	{0, t.TOKEN_DOCUMENT_HEADER, ""},
+	{1, t.TOKEN_HEADER, ""},
+	{2, t.TOKEN_NORMAL_TEXT, "Title"},
+	{1, t.TOKEN_PARAGRAPH, ""},
+	{2, t.TOKEN_NORMAL_TEXT, "some "},
+	{2, t.TOKEN_EMPHASIS, ""},
+	{3, t.TOKEN_NORMAL_TEXT, "em"},
+	{2, t.TOKEN_NORMAL_TEXT, " text"},
+	{1, t.TOKEN_LIST, ""},
+	{2, t.TOKEN_LIST_ITEM, ""},
+	{3, t.TOKEN_NORMAL_TEXT, "one"},
+	{3, t.TOKEN_NORMAL_TEXT, "\n"},
+	{2, t.TOKEN_LIST_ITEM, ""},
+	{3, t.TOKEN_NORMAL_TEXT, "two"},
+	{3, t.TOKEN_NORMAL_TEXT, "\n"},
+}
+
+func TestNodeTree(x *testing.T) {
+	var n, depth = 0, 0
+	Parse([]byte(nodeDoc), extensions).Walk(func(e t.NodeType, c *Node) bool {
+		if e == t.NODE_CLOSE {
+			depth--
+			return true
+		}
+		var text = ""
+		if c.Kind() == t.TOKEN_NORMAL_TEXT {
+			text = c.String()
+		}
+		if n >= len(nodeTreeTests) {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed Test Nr. %d: unexpected node %s %q", n, c.Kind(), text))
+		} else if test := nodeTreeTests[n]; depth != test.depth || c.Kind() != test.kind || text != test.text {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed Test Nr. %d: got: %d %s %q expected: %d %s %q",
+				n, depth, c.Kind(), text, test.depth, test.kind, test.text))
+		}
+		n++
+		depth++
+		return true
+	})
+	if n != len(nodeTreeTests) {
+		x.Fail()
+		x.Log(fmt.Sprintf("failed: got %d nodes expected: %d", n, len(nodeTreeTests)))
+	}
+}
+
+// expected values are passed as natives. Strings are text, slices become
+// lists of their converted elements.
+func nodeExpected(v interface{}) t.Evaluable {
+	switch y := v.(type) {
+	case string:
+		return t.NewText(y)
+	case []interface{}:
+		var l = []t.Evaluable{}
+		for _, e := range y {
+			l = append(l, nodeExpected(e))
+		}
+		return t.NewArrayList(l...)
+	}
+	return t.Value(v)
+}
+
+// function type, to be passed in the test slug
+type nodeTestFunc func(root *Node) t.Evaluable
+
+// the operand is the root of the parsed document. Results of more than one
+// value are returned as list.
+var nodeTests = []struct {
+	typ   t.ValueType
+	exp   interface{}
+	opStr string
+	op    nodeTestFunc
+}{
+	{t.FLAG, t.TOKEN_DOCUMENT_HEADER, "root kind", func(root *Node) t.Evaluable { return root.Kind() }},
+	{t.BOOL, true, "root parent", func(root *Node) t.Evaluable { return t.Value(root.Parent() == nil) }},
+	{t.BOOL, true, "root flag", func(root *Node) t.Evaluable { return t.Value(root.Flag()&t.NODE_ROOT != 0) }},
+	{t.FLAG, t.NODE, "Type", func(root *Node) t.Evaluable { return root.Type() }},
+	{t.LIST, []interface{}{t.TOKEN_HEADER, t.TOKEN_PARAGRAPH, t.TOKEN_LIST}, "Children", func(root *Node) t.Evaluable {
+		var r = []t.Evaluable{}
+		for _, c := range root.Children().Values() {
+			r = append(r, c.(*Node).Kind())
+		}
+		return t.NewArrayList(r...)
+	}},
+	{t.BOOL, true, "Parent", func(root *Node) t.Evaluable {
+		var ok = true
+		root.Walk(func(e t.NodeType, n *Node) bool {
+			for _, c := range n.children {
+				ok = ok && c.Parent() == n
+			}
+			return true
+		})
+		return t.Value(ok)
+	}},
+	{t.LIST, []interface{}{"Title", "some ", "em", " text", "one", "\n", "two", "\n"}, "leaves", func(root *Node) t.Evaluable {
+		var r = []t.Evaluable{}
+		root.Walk(func(e t.NodeType, n *Node) bool {
+			if e == t.NODE_OPEN && n.Flag()&t.NODE_LEAF != 0 {
+				r = append(r, t.NewText(n.String()))
+			}
+			return true
+		})
+		return t.NewArrayList(r...)
+	}},
+	{t.INTEGER, 0, "no empty text", func(root *Node) t.Evaluable {
+		var n = 0
+		Parse([]byte("*lead* text [link](/x)\n\n| `a` |\n|---|\n| *b* |\n"), extensions).Walk(func(e t.NodeType, c *Node) bool {
+			if c.Kind() == t.TOKEN_NORMAL_TEXT && c.String() == "" {
+				n++
+			}
+			return true
+		})
+		return t.NewInteger(int64(n))
+	}},
+	{t.LIST, []interface{}{2, 1}, "Attributes", func(root *Node) t.Evaluable {
+		var h = root.children[0]
+		var level, _ = h.Attributes().(Attributes).Get(t.NewBytes([]byte("level")))
+		return t.NewArrayList(t.NewInteger(int64(h.Attributes().Size())), level)
+	}},
+	{t.LIST, []interface{}{false, 3}, "Walk canceled", func(root *Node) t.Evaluable {
+		var n = 0
+		var done = root.Walk(func(e t.NodeType, _ *Node) bool {
+			n++
+			return n < 3
+		})
+		return t.NewArrayList(t.Value(done), t.NewInteger(int64(n)))
+	}},
+	{t.LIST, []interface{}{15, 15}, "Walk events", func(root *Node) t.Evaluable {
+		var open, close = 0, 0
+		root.Walk(func(e t.NodeType, _ *Node) bool {
+			if e == t.NODE_OPEN {
+				open++
+			} else {
+				close++
+			}
+			return true
+		})
+		return t.NewArrayList(t.NewInteger(int64(open)), t.NewInteger(int64(close)))
+	}},
+}
+
+func TestNode(x *testing.T) {
+	for n, test := range nodeTests {
+		var res, exp = test.op(Parse([]byte(nodeDoc), extensions)), nodeExpected(test.exp)
+		if !t.Equal(res, exp) || res.Type() != test.typ {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed Test Nr. %d: %s got: %s %q expected: %s %q",
+				n, test.opStr, res.Type(), res.String(), test.typ, exp.String()))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	b "github.com/russross/blackfriday"
+)
+
+// returned on the error channel, when the parser panics for reasons other
+// than cancellation
+var ErrParser = errors.New("tokens: parser failed")
+
+// canceled is thrown by the renderer to unwind the parser, once the context
+// got canceled while a token was waiting to be received.
+type canceled struct{ err error }
+
+// Tokenize parses the markdown document read from the passed reader in a
+// separate go routine and pushes each token on to the returned channel, as
+// soon as the renderer callback emitting it is called. Since the channel is
+// unbuffered, the parser only proceeds as fast as the consumer receives
+// tokens. Both channels get closed at the end of the document. Errors reading
+// the document, or parsing it, as well as cancellation of the context are
+// reported on the error channel, before it gets closed.
+//
+// blackfriday needs the complete document to resolve references and
+// footnotes, so the document itself is read at once, tokens are not buffered
+// at all.
+func Tokenize(ctx context.Context, r io.Reader, extensions int) (<-chan Token, <-chan error) {
+	var tok = make(chan Token)
+	var errs = make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(tok)
+		defer func() {
+			if rec := recover(); rec != nil {
+				if c, ok := rec.(canceled); ok {
+					errs <- c.err
+				} else {
+					errs <- fmt.Errorf("%v: %v", ErrParser, rec)
+				}
+			}
+		}()
+
+		input, err := ioutil.ReadAll(r)
+		if err != nil {
+			errs <- err
+			return
+		}
+		if err := ctx.Err(); err != nil {
+			errs <- err
+			return
+		}
+		b.Markdown(input, Renderer{tok: tok, ctx: ctx}, extensions)
+	}()
+
+	return tok, errs
+}
@@ -0,0 +1,47 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+	b "github.com/russross/blackfriday"
+)
+
+var streamDoc = "# Header\n\nsome *emphasized* text\n\n- one\n- two\n"
+
+// all tokens get received and both channels get closed at the end of the
+// document
+func TestTokenize(x *testing.T) {
+	tok, errs := Tokenize(context.Background(), strings.NewReader(streamDoc), b.EXTENSION_TABLES)
+
+	var kinds = []t.TokenType{}
+	for k := range tok {
+		kinds = append(kinds, k.Kind())
+	}
+	if err, ok := <-errs; ok {
+		x.Fatal("unexpected error: " + fmt.Sprint(err))
+	}
+	if kinds[0] != t.TOKEN_DOCUMENT_HEADER || kinds[len(kinds)-1] != t.TOKEN_DOCUMENT_FOOTER {
+		x.Fail()
+		x.Log("failed: document not enclosed by header and footer: " + fmt.Sprint(kinds))
+	}
+}
+
+// canceling the context while tokens are still pending, stops the parser and
+// reports the cancellation
+func TestTokenizeCancel(x *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tok, errs := Tokenize(ctx, strings.NewReader(streamDoc), b.EXTENSION_TABLES)
+
+	<-tok
+	cancel()
+	for range tok {
+	}
+	if err := <-errs; err != context.Canceled {
+		x.Fail()
+		x.Log("failed: got: " + fmt.Sprint(err) + " expected: " + fmt.Sprint(context.Canceled))
+	}
+}
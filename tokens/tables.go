@@ -0,0 +1,180 @@
+package tokens
+
+import (
+	"bytes"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// TABLES
+///
+// markdown tables get extracted as labeled tables. The text of the header
+// cells becomes the key of each column, empty, or repeated header cells are
+// keyed by the position of their column, counting from one. Rows are keyed
+// by their position in the body of the table, counting from one as well.
+// Cells are typed by their plain text: numbers become Integer, decimals and
+// fractions Ratio, empty cells Empty and everything else Text. The alignment
+// of each column is kept alongside, mapping the column keys on to the
+// alignment flags passed to the table callback.
+type Table struct {
+	t.LabeledTable
+	align Attributes
+	node  *Node // nil, if the table got collected from a token stream
+}
+
+// Alignment maps the column keys on to their alignment flags
+func (x Table) Alignment() t.Mapped { return x.align }
+
+// Node returns the node the table got extracted from, nil if it got collected
+// from a token stream
+func (x Table) Node() *Node { return x.node }
+
+// Tables returns all tables contained in the tree rooted at the passed node,
+// in the order of their appearance.
+func Tables(n *Node) []Table {
+	var r = []Table{}
+	n.Walk(func(e t.NodeType, n *Node) bool {
+		if e != t.NODE_OPEN || n.kind != t.TOKEN_TABLE {
+			return true
+		}
+		var head, body = [][]byte{}, [][][]byte{}
+		for _, row := range n.children {
+			var cells = [][]byte{}
+			for _, c := range row.children {
+				cells = append(cells, cellText(c))
+			}
+			if len(row.children) > 0 && row.children[0].kind == t.TOKEN_TABLE_HEADER_CELL {
+				head = cells
+				continue
+			}
+			body = append(body, cells)
+		}
+		r = append(r, newTable(head, body, n.parm, n))
+		return true
+	})
+	return r
+}
+
+// CollectTables receives tokens until the channel gets closed and returns
+// all tables they contain. The cells of a table arrive first, each row after
+// its cells and the table itself after all of its rows. The spans of a cell
+// arrive ahead of it, so that formulas get restored from their markdown, as
+// they are on the tree.
+func CollectTables(tok <-chan Token) []Table {
+	var r = []Table{}
+	var head, body, cells = [][]byte{}, [][][]byte{}, [][]byte{}
+	var spans = []*Node{}
+	var header bool
+	for k := range tok {
+		switch k.kind {
+		case t.TOKEN_TABLE_HEADER_CELL, t.TOKEN_TABLE_CELL:
+			header = k.kind == t.TOKEN_TABLE_HEADER_CELL
+			var c = &Node{Token: k, flag: nodeType(k.kind)}
+			c.adopt(spans...)
+			cells, spans = append(cells, cellText(c)), []*Node{}
+			continue
+		case t.TOKEN_TABLE_ROW:
+			if header {
+				head = cells
+			} else {
+				body = append(body, cells)
+			}
+			cells, header = [][]byte{}, false
+		case t.TOKEN_TABLE:
+			r = append(r, newTable(head, body, k.parm, nil))
+			head, body = [][]byte{}, [][][]byte{}
+		}
+		if (t.TOKEN_SPAN | t.TOKEN_LOW_LEVEL).Match(k.kind) {
+			spans = spanOf(spans, k)
+			continue
+		}
+		spans = []*Node{}
+	}
+	return r
+}
+
+// spans enclosing content arrive after the spans of that content
+const enclosing = t.TOKEN_EMPHASIS | t.TOKEN_DOUBLE_EMPHASIS | t.TOKEN_TRIPLE_EMPHASIS |
+	t.TOKEN_STRIKE_THROUGH | t.TOKEN_LINK
+
+// appends the node of the span token to the preceding spans. Enclosing spans
+// adopt the preceding spans, whose payloads make up their own, empty text is
+// dropped.
+func spanOf(spans []*Node, k Token) []*Node {
+	var val = k.val.Serialize()
+	if k.kind == t.TOKEN_NORMAL_TEXT && len(val) == 0 {
+		return spans
+	}
+	var n = &Node{Token: k, flag: nodeType(k.kind)}
+	if enclosing.Match(k.kind) {
+		var text = []byte{}
+		for i := len(spans) - 1; i >= 0 && len(text) < len(val); i-- {
+			text = append(append([]byte{}, spans[i].val.Serialize()...), text...)
+			if bytes.Equal(text, val) {
+				n.adopt(spans[i:]...)
+				spans = spans[:i]
+				break
+			}
+		}
+	}
+	return append(spans, n)
+}
+
+// assembles the labeled table from the plain text of the header and body
+// cells. Alignment flags are passed as pairs of column index and flags.
+func newTable(head [][]byte, body [][][]byte, columnData []t.Pair, n *Node) Table {
+	var cols = []t.Evaluable{}
+	var l, _ = t.NewLabeledTable()
+	for i, h := range head {
+		var k t.Evaluable = t.NewText(string(bytes.TrimSpace(h)))
+		if len(bytes.TrimSpace(h)) == 0 || containsKey(cols, k) {
+			k = t.NewInteger(int64(i + 1))
+		}
+		cols = append(cols, k)
+		l, _ = l.AddColumn(k)
+	}
+	for i, row := range body {
+		var v = []t.Evaluable{}
+		for j := 0; j < len(row) && j < len(cols); j++ {
+			v = append(v, CellValue(row[j]))
+		}
+		l, _ = l.AddRow(t.NewInteger(int64(i+1)), v...)
+	}
+	var align = Attributes{}
+	for _, p := range columnData {
+		if i := int(p.Index()().Int64()); i >= 0 && i < len(cols) {
+			align = append(align, t.Value(cols[i], p.Value()).(t.Pair))
+		}
+	}
+	return Table{l, align, n}
+}
+
+// CellValue types the plain text of a table cell. Integral numbers become
+// Integer, decimals and fractions Ratio, unless they are integral as well.
+// Empty cells yield an empty value, all other cells Text.
+func CellValue(text []byte) t.Evaluable {
+	var s = string(bytes.TrimSpace(text))
+	if s == "" {
+		return t.Empty(nil).Eval()
+	}
+	if i, ok := t.ParseInteger(s); ok {
+		return i
+	}
+	if r, ok := t.ParseRatio(s); ok {
+		if r.IsInt() {
+			return r.Num()
+		}
+		return r
+	}
+	return t.Value(s)
+}
+
+func containsKey(keys []t.Evaluable, k t.Evaluable) bool {
+	for _, x := range keys {
+		if t.Equal(x, k) {
+			return true
+		}
+	}
+	return false
+}
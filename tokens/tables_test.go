@@ -0,0 +1,107 @@
+package tokens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+	b "github.com/russross/blackfriday"
+)
+
+var tablesDoc = `# Estimates
+
+| Task  | Days | Rate | Note |
+|:------|-----:|:----:|------|
+| setup | 2    | 1.5  |      |
+| api   | 5    | 3/4  | *later* |
+
+| Risk | Impact | Impact |
+|------|--------|--------|
+| x    | 0.25   | 2.0    |
+`
+
+// cells of the table as lines of tab delimited values, each prefixed by its
+// type and led by the keys of the columns
+func tableLines(x Table) string {
+	var r = fmt.Sprint(x.ColumnKeys())
+	for _, k := range x.RowKeys() {
+		r = r + "\n" + k.String() + ":"
+		for _, c := range x.ColumnKeys() {
+			var v = x.Element(k, c)
+			r = r + " " + v.Type().String() + "(" + v.String() + ")"
+		}
+	}
+	return r
+}
+
+var tablesExp = []string{
+	"[Task Days Rate Note]\n" +
+		"1: TEXT(setup) INTEGER(2) RATIONAL(3/2) EMPTY(EMPTY)\n" +
+		"2: TEXT(api) INTEGER(5) RATIONAL(3/4) TEXT(later)",
+	"[Risk Impact 3]\n" +
+		"1: TEXT(x) RATIONAL(1/4) INTEGER(2)",
+}
+
+func testTables(x *testing.T, tables []Table) {
+	if len(tables) != len(tablesExp) {
+		x.Fatal("failed: got " + fmt.Sprint(len(tables)) + " tables expected: " + fmt.Sprint(len(tablesExp)))
+	}
+	for i, exp := range tablesExp {
+		if got := tableLines(tables[i]); got != exp {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed table Nr. %d got: %q expected: %q", i, got, exp))
+		}
+	}
+	var align = []string{}
+	for _, k := range tables[0].ColumnKeys() {
+		v, _ := tables[0].Alignment().(Attributes).Get(k)
+		align = append(align, v.String())
+	}
+	var exp = fmt.Sprint(b.TABLE_ALIGNMENT_LEFT, b.TABLE_ALIGNMENT_RIGHT, b.TABLE_ALIGNMENT_CENTER, 0)
+	if got := strings.Join(align, " "); got != exp {
+		x.Fail()
+		x.Log("failed alignment got: " + got + " expected: " + exp)
+	}
+}
+
+func TestTables(x *testing.T) {
+	var tables = Tables(Parse([]byte(tablesDoc), extensions))
+	testTables(x, tables)
+	if tables[1].Node() == nil || tables[1].Node().kind != t.TOKEN_TABLE {
+		x.Fail()
+		x.Log("failed: table not linked to its node")
+	}
+	var m, ok = tables[1].RemoveColumn(t.NewText("Risk")).Matrix()
+	if !ok || fmt.Sprint(m.Shape()) != "[1 2]" {
+		x.Fail()
+		x.Log("failed: numeric columns not convertible to matrix")
+	}
+}
+
+// tables collected from the token stream equal those extracted from the tree
+func TestCollectTables(x *testing.T) {
+	var tok, errs = Tokenize(context.Background(), strings.NewReader(tablesDoc), extensions)
+	var tables = CollectTables(tok)
+	if err, ok := <-errs; ok {
+		x.Fatal("unexpected error: " + fmt.Sprint(err))
+	}
+	testTables(x, tables)
+	if tables[0].Node() != nil {
+		x.Fail()
+		x.Log("failed: streamed table refers to a node")
+	}
+	// formulas are restored from the markdown of their cells, like on the tree
+	var doc = "| A | B | C | D |\n|---|---|---|---|\n| 2 | 3 | 4 | =A2*B2*C2 |\n| =(A2+1)*B2*2 | **x** | `y` | =SUM(A2:C2)*2 |\n"
+	tok, errs = Tokenize(context.Background(), strings.NewReader(doc), extensions)
+	tables = CollectTables(tok)
+	if err, ok := <-errs; ok {
+		x.Fatal("unexpected error: " + fmt.Sprint(err))
+	}
+	var exp = strings.Join(evaluated(doc), "|")
+	if got := strings.Join(evaluatedRows(tables[0]), "|"); got != exp || exp != "2 3 4 24|18 x y 18" {
+		x.Fail()
+		x.Log(fmt.Sprintf("failed formulas got: %q tree: %q expected: %q", got, exp, "2 3 4 24|18 x y 18"))
+	}
+}
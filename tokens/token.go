@@ -2,24 +2,268 @@ package tokens
 
 import (
 	"bytes"
+	"context"
 	t "github.com/JoergReinhardt/blackfriday/types"
-	//b "github.com/russross/blackfriday"
+	b "github.com/russross/blackfriday"
 )
 
+// a token carries the type of the callback it got emitted by, the payload
+// handed to that callback and all parameters that came along with it as
+// key/value pairs.
 type Token struct {
+	kind t.TokenType
 	val  t.Bytes
 	parm []t.Pair
 }
 
+func (k Token) Kind() t.TokenType { return k.kind }
+func (k Token) Value() t.Bytes    { return k.val }
+func (k Token) Parms() []t.Pair   { return k.parm }
+
+// Renderer implements the blackfriday renderer interface. Instead of
+// rendering html, or latex, every callback pushes a token on to the channel.
+// Nested content gets written to the output buffer as plain text, so that the
+// callbacks of enclosing elements receive the text of all their children as
+// payload. If a tree is attached, all tokens get assembled to a document tree
+// as well.
 type Renderer struct {
-	tok chan<- Token
+	tok   chan<- Token
+	ctx   context.Context // optional, cancels sending on tok
+	flags int
+	tree  *tree // optional, builds the document tree alongside
+}
+
+// make shure, the renderer can be passed on to blackfriday
+var _ b.Renderer = Renderer{}
+
+//////////////////////////////////////////////////////////////////////////
+//// HELPERS
+///
+// push passes a token on to the channel and to the tree, if either of them
+// is attached to the renderer. Tokens nested in the text closure of the
+// callback got pushed to the same buffer, starting at the mark passed as
+// from. Tokens nested in byte slice payloads, got pushed to the buffer the
+// source slices have been taken from. If the context gets canceled, while
+// the token waits to be received, the parser is unwound by a panic, which is
+// recovered by Tokenize.
+func (r Renderer) push(out *bytes.Buffer, from int, k Token, src ...[]byte) {
+	if r.tok != nil {
+		if r.ctx != nil {
+			if err := r.ctx.Err(); err != nil {
+				panic(canceled{err})
+			}
+			select {
+			case r.tok <- k:
+			case <-r.ctx.Done():
+				panic(canceled{r.ctx.Err()})
+			}
+		} else {
+			r.tok <- k
+		}
+	}
+	if r.tree != nil {
+		r.tree.add(out, from, k, src...)
+	}
+}
+
+// emit pushes a token with the passed payload and parameters
+func (r Renderer) emit(kind t.TokenType, out *bytes.Buffer, val []byte, parm ...t.Pair) {
+	r.push(out, -1, Token{
+		kind,
+		t.NewBytes(val),
+		parm,
+	}, val)
+}
+
+// span emits a token and writes its payload to the output buffer as plain
+// text, so that enclosing elements receive it as part of their payload
+func (r Renderer) span(kind t.TokenType, out *bytes.Buffer, val []byte, parm ...t.Pair) {
+	r.emit(kind, out, val, parm...)
+	out.Write(val)
+}
+
+// block emits a token and writes its payload to the output buffer, followed
+// by a newline, if the payload does not end with one allready
+func (r Renderer) block(kind t.TokenType, out *bytes.Buffer, val []byte, parm ...t.Pair) {
+	r.emit(kind, out, val, parm...)
+	out.Write(val)
+	endBlock(out)
+}
+
+// nested calls the text closure passed to callbacks with nested content. All
+// tokens of the nested content get pushed by the callbacks text calls, the
+// plain text they write to the buffer is returned to become the payload of
+// the enclosing token. If text fails, the buffer is reset to its previous
+// state, as the html renderer does.
+func (r Renderer) nested(kind t.TokenType, out *bytes.Buffer, text func() bool, parm ...t.Pair) {
+	var from int
+	if r.tree != nil {
+		from = r.tree.mark(out)
+	}
+	marker := out.Len()
+	if !text() {
+		out.Truncate(marker)
+		if r.tree != nil {
+			r.tree.reset(out, from)
+		}
+		return
+	}
+	r.push(out, from, Token{
+		kind,
+		t.NewBytes(out.Bytes()[marker:]),
+		parm,
+	})
+	endBlock(out)
+}
+
+// parameters get paired with their name as key. Names and symbolic
+// parameters are stored as Bytes, numeric ones as integer values.
+func parm(name string, v interface{}) t.Pair {
+	var k = t.NewBytes([]byte(name))
+	switch v := v.(type) {
+	case string:
+		return t.Value(k, t.NewBytes([]byte(v))).(t.Pair)
+	case []byte:
+		return t.Value(k, t.NewBytes(v)).(t.Pair)
+	}
+	return t.Value(k, t.Value(v)).(t.Pair)
+}
+
+// terminate block level content by a newline
+func endBlock(out *bytes.Buffer) {
+	if l := out.Len(); l > 0 && out.Bytes()[l-1] != '\n' {
+		out.WriteByte('\n')
+	}
 }
 
+//////////////////////////////////////////////////////////////////////////
+//// BLOCK LEVEL CALLBACKS
+///
 func (r Renderer) BlockCode(out *bytes.Buffer, text []byte, lang string) {
-	r.tok <- Token{
-		t.Value(text).(t.Bytes),
-		[]t.Pair{
-			t.Value("lang", lang).(t.Pair),
-		},
+	r.block(t.TOKEN_BLOCK_CODE, out, text, parm("lang", lang))
+}
+func (r Renderer) BlockQuote(out *bytes.Buffer, text []byte) {
+	r.block(t.TOKEN_BLOCK_QUOTE, out, text)
+}
+func (r Renderer) BlockHtml(out *bytes.Buffer, text []byte) {
+	r.block(t.TOKEN_BLOCK_HTML, out, text)
+}
+func (r Renderer) Header(out *bytes.Buffer, text func() bool, level int, id string) {
+	r.nested(t.TOKEN_HEADER, out, text,
+		parm("level", level),
+		parm("id", id),
+	)
+}
+func (r Renderer) HRule(out *bytes.Buffer) {
+	r.block(t.TOKEN_HRULE, out, nil)
+}
+func (r Renderer) List(out *bytes.Buffer, text func() bool, flags int) {
+	r.nested(t.TOKEN_LIST, out, text, parm("flags", flags))
+}
+func (r Renderer) ListItem(out *bytes.Buffer, text []byte, flags int) {
+	r.block(t.TOKEN_LIST_ITEM, out, text, parm("flags", flags))
+}
+func (r Renderer) Paragraph(out *bytes.Buffer, text func() bool) {
+	r.nested(t.TOKEN_PARAGRAPH, out, text)
+}
+
+// the alignment of each column is passed as a pair, keyed by the index of the
+// column it belongs to.
+func (r Renderer) Table(out *bytes.Buffer, header []byte, body []byte, columnData []int) {
+	var cols = []t.Pair{}
+	for i, c := range columnData {
+		cols = append(cols, t.Value(i, c).(t.Pair))
 	}
+	var val = append(append([]byte{}, header...), body...)
+	r.push(out, -1, Token{t.TOKEN_TABLE, t.NewBytes(val), cols}, header, body)
+	out.Write(val)
+	endBlock(out)
+}
+func (r Renderer) TableRow(out *bytes.Buffer, text []byte) {
+	r.block(t.TOKEN_TABLE_ROW, out, text)
+}
+func (r Renderer) TableHeaderCell(out *bytes.Buffer, text []byte, flags int) {
+	r.span(t.TOKEN_TABLE_HEADER_CELL, out, text, parm("flags", flags))
+}
+func (r Renderer) TableCell(out *bytes.Buffer, text []byte, flags int) {
+	r.span(t.TOKEN_TABLE_CELL, out, text, parm("flags", flags))
+}
+func (r Renderer) Footnotes(out *bytes.Buffer, text func() bool) {
+	r.nested(t.TOKEN_FOOTNOTES, out, text)
+}
+func (r Renderer) FootnoteItem(out *bytes.Buffer, name, text []byte, flags int) {
+	r.block(t.TOKEN_FOOTNOTE_ITEM, out, text,
+		parm("name", name),
+		parm("flags", flags),
+	)
+}
+func (r Renderer) TitleBlock(out *bytes.Buffer, text []byte) {
+	r.block(t.TOKEN_TITLE_BLOCK, out, text)
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// SPAN LEVEL CALLBACKS
+///
+func (r Renderer) AutoLink(out *bytes.Buffer, link []byte, kind int) {
+	r.span(t.TOKEN_AUTO_LINK, out, link,
+		parm("link", link),
+		parm("kind", kind),
+	)
+}
+func (r Renderer) CodeSpan(out *bytes.Buffer, text []byte) {
+	r.span(t.TOKEN_CODE_SPAN, out, text)
+}
+func (r Renderer) DoubleEmphasis(out *bytes.Buffer, text []byte) {
+	r.span(t.TOKEN_DOUBLE_EMPHASIS, out, text)
+}
+func (r Renderer) Emphasis(out *bytes.Buffer, text []byte) {
+	r.span(t.TOKEN_EMPHASIS, out, text)
 }
+
+// images contribute their alternative text to the enclosing payload
+func (r Renderer) Image(out *bytes.Buffer, link []byte, title []byte, alt []byte) {
+	r.span(t.TOKEN_IMAGE, out, alt,
+		parm("link", link),
+		parm("title", title),
+		parm("alt", alt),
+	)
+}
+func (r Renderer) LineBreak(out *bytes.Buffer) {
+	r.span(t.TOKEN_LINE_BREAK, out, []byte("\n"))
+}
+func (r Renderer) Link(out *bytes.Buffer, link []byte, title []byte, content []byte) {
+	r.span(t.TOKEN_LINK, out, content,
+		parm("link", link),
+		parm("title", title),
+	)
+}
+func (r Renderer) RawHtmlTag(out *bytes.Buffer, tag []byte) {
+	r.span(t.TOKEN_RAW_HTML_TAG, out, tag)
+}
+func (r Renderer) TripleEmphasis(out *bytes.Buffer, text []byte) {
+	r.span(t.TOKEN_TRIPLE_EMPHASIS, out, text)
+}
+func (r Renderer) StrikeThrough(out *bytes.Buffer, text []byte) {
+	r.span(t.TOKEN_STRIKE_THROUGH, out, text)
+}
+func (r Renderer) FootnoteRef(out *bytes.Buffer, ref []byte, id int) {
+	r.span(t.TOKEN_FOOTNOTE_REF, out, ref, parm("id", id))
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// LOW LEVEL CALLBACKS
+///
+func (r Renderer) Entity(out *bytes.Buffer, entity []byte) {
+	r.span(t.TOKEN_ENTITY, out, entity)
+}
+func (r Renderer) NormalText(out *bytes.Buffer, text []byte) {
+	r.span(t.TOKEN_NORMAL_TEXT, out, text)
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// HEADER & FOOTER
+///
+func (r Renderer) DocumentHeader(out *bytes.Buffer) { r.emit(t.TOKEN_DOCUMENT_HEADER, out, nil) }
+func (r Renderer) DocumentFooter(out *bytes.Buffer) { r.emit(t.TOKEN_DOCUMENT_FOOTER, out, nil) }
+
+func (r Renderer) GetFlags() int { return r.flags }
@@ -0,0 +1,126 @@
+package tokens
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+)
+
+// each callback pushes one token, carrying its parameters as pairs keyed by
+// name and writes its payload to the output buffer
+var rendererTests = []struct {
+	call func(r Renderer, out *bytes.Buffer)
+	tok  string
+	out  string
+}{
+	{
+		func(r Renderer, out *bytes.Buffer) { r.BlockCode(out, []byte("x := 1"), "go") },
+		`TOKEN_BLOCK_CODE "x := 1" lang=go`, "x := 1\n",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) {
+			r.Header(out, func() bool { out.WriteString("Title"); return true }, 2, "title")
+		},
+		`TOKEN_HEADER "Title" level=2 id=title`, "Title\n",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.ListItem(out, []byte("one"), 17) },
+		`TOKEN_LIST_ITEM "one" flags=17`, "one\n",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.Table(out, []byte("a|b\n"), []byte("1|2\n"), []int{1, 3}) },
+		`TOKEN_TABLE "a|b\n1|2\n" 0=1 1=3`, "a|b\n1|2\n",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.TableCell(out, []byte("1/3"), 2) },
+		`TOKEN_TABLE_CELL "1/3" flags=2`, "1/3",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.FootnoteItem(out, []byte("note"), []byte("text"), 4) },
+		`TOKEN_FOOTNOTE_ITEM "text" name=note flags=4`, "text\n",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.AutoLink(out, []byte("http://x.org"), 1) },
+		`TOKEN_AUTO_LINK "http://x.org" link=http://x.org kind=1`, "http://x.org",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.Image(out, []byte("a.png"), []byte("A"), []byte("alt")) },
+		`TOKEN_IMAGE "alt" link=a.png title=A alt=alt`, "alt",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.Link(out, []byte("/x"), nil, []byte("here")) },
+		`TOKEN_LINK "here" link=/x title=`, "here",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.FootnoteRef(out, []byte("1"), 1) },
+		`TOKEN_FOOTNOTE_REF "1" id=1`, "1",
+	},
+	{
+		func(r Renderer, out *bytes.Buffer) { r.HRule(out) },
+		`TOKEN_HRULE ""`, "",
+	},
+}
+
+func TestRenderer(x *testing.T) {
+	for n, test := range rendererTests {
+		var tok = make(chan Token, 1)
+		var out = &bytes.Buffer{}
+		test.call(Renderer{tok: tok}, out)
+		close(tok)
+		var k, ok = <-tok
+		if !ok {
+			x.Fatal(fmt.Sprintf("failed Test Nr. %d: no token pushed", n))
+		}
+		if got := tokenString(k); got != test.tok {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed Test Nr. %d got: %q expected: %q", n, got, test.tok))
+		}
+		if out.String() != test.out {
+			x.Fail()
+			x.Log(fmt.Sprintf("failed Test Nr. %d output got: %q expected: %q", n, out.String(), test.out))
+		}
+		// pairs serialize without panicking, keyed by name, or column index
+		for _, p := range k.Parms() {
+			if p.String() == "" || p.Key().Type()&(t.BYTES|t.NATURAL) == 0 {
+				x.Fail()
+				x.Log(fmt.Sprintf("failed Test Nr. %d: malformed parameter %q", n, p.String()))
+			}
+		}
+	}
+}
+
+var kindsDoc = "# Title\n\n" +
+	"Text with *em*, **strong**, ~~gone~~, `code`, a [link](/x) and ![img](a.png)&amp;.\n\n" +
+	"- item\n\n" +
+	"> quote\n\n" +
+	"---\n\n" +
+	"| a |\n|---|\n| 1 |\n"
+
+// every token carries the single kind of the callback that emitted it
+func TestTokenKinds(x *testing.T) {
+	var tok, errs = Tokenize(context.Background(), strings.NewReader(kindsDoc), extensions)
+	var kinds t.TokenType
+	for k := range tok {
+		if len(k.Kind().Kinds()) != 1 {
+			x.Fail()
+			x.Log("failed: token of kind " + k.Kind().String())
+		}
+		kinds = kinds | k.Kind()
+	}
+	if err, ok := <-errs; ok {
+		x.Fatal("unexpected error: " + fmt.Sprint(err))
+	}
+	var exp = t.TOKEN_DOCUMENT | t.TOKEN_HEADER | t.TOKEN_PARAGRAPH | t.TOKEN_EMPHASIS |
+		t.TOKEN_DOUBLE_EMPHASIS | t.TOKEN_STRIKE_THROUGH | t.TOKEN_CODE_SPAN | t.TOKEN_LINK |
+		t.TOKEN_IMAGE | t.TOKEN_ENTITY | t.TOKEN_NORMAL_TEXT | t.TOKEN_LIST | t.TOKEN_LIST_ITEM |
+		t.TOKEN_BLOCK_QUOTE | t.TOKEN_HRULE | t.TOKEN_TABLE | t.TOKEN_TABLE_ROW |
+		t.TOKEN_TABLE_HEADER_CELL | t.TOKEN_TABLE_CELL
+	if kinds != exp {
+		x.Fail()
+		x.Log("failed: got kinds: " + kinds.String() + " expected: " + exp.String())
+	}
+}
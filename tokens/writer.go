@@ -0,0 +1,363 @@
+package tokens
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+	b "github.com/russross/blackfriday"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// MARKDOWN WRITER
+///
+// the writer regenerates normalized markdown from a document tree. Headers
+// are written in atx style, code blocks fenced, lists get marked by '-', or
+// '1.', each item indented by four spaces, emphasis is marked by asterisks.
+// Parsing the written markdown again yields the same tokens, the document
+// tree has been assembled from.
+
+// Write renders the tree rooted at the passed node as markdown
+func Write(w io.Writer, n *Node) error {
+	_, err := w.Write(Markdown(n))
+	return err
+}
+
+// Markdown returns the tree rooted at the passed node rendered as markdown.
+// If the node is not the document root, the node is rendered as if it would
+// be the only element of the document.
+func Markdown(n *Node) []byte {
+	if n.Flag()&t.NODE_ROOT != 0 {
+		return blocks(n.children)
+	}
+	if n.Flag()&t.NODE_BLOCK != 0 {
+		return block(n)
+	}
+	return append(inline(n), '\n')
+}
+
+// attr returns the attribute of the node with the passed name as string
+func (n *Node) attr(name string) string {
+	if v, ok := Attributes(n.parm).Get(t.NewBytes([]byte(name))); ok {
+		return v.String()
+	}
+	return ""
+}
+
+// flags returns a numeric attribute of the node as native integer
+func (n *Node) flags(name string) int {
+	i, _ := strconv.Atoi(n.attr(name))
+	return i
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// BLOCK LEVEL
+///
+// consecutive blocks are seperated by an empty line
+func blocks(n []*Node) []byte {
+	var r = [][]byte{}
+	for _, c := range n {
+		if v := block(c); len(v) > 0 {
+			r = append(r, v)
+		}
+	}
+	return bytes.Join(r, []byte("\n"))
+}
+
+// block renders a block level node, the result is terminated by a newline
+func block(n *Node) []byte {
+	var r []byte
+	switch n.kind {
+	case t.TOKEN_HEADER:
+		r = append([]byte(strings.Repeat("#", n.flags("level"))+" "),
+			bytes.TrimSpace(inline(n.children...))...)
+		if id := n.attr("id"); id != "" {
+			r = append(r, []byte(" {#"+id+"}")...)
+		}
+	case t.TOKEN_PARAGRAPH:
+		r = bytes.TrimRight(inline(n.children...), " \n")
+	case t.TOKEN_BLOCK_CODE:
+		r = codeBlock(n)
+	case t.TOKEN_BLOCK_QUOTE:
+		r = indent(blocks(n.children), "> ", "> ")
+	case t.TOKEN_BLOCK_HTML, t.TOKEN_TITLE_BLOCK:
+		r = n.val.Serialize()
+	case t.TOKEN_HRULE:
+		r = []byte("* * *")
+	case t.TOKEN_LIST:
+		r = list(n)
+	case t.TOKEN_TABLE:
+		r = table(n)
+	case t.TOKEN_FOOTNOTES:
+		r = footnotes(n)
+	default: // span level content where blocks are expected
+		r = bytes.TrimRight(inline(n), " \n")
+	}
+	return append(bytes.TrimRight(r, "\n"), '\n')
+}
+
+// code blocks get fenced by more backticks, than the longest sequence of
+// backticks contained in the code
+func codeBlock(n *Node) []byte {
+	var code = n.val.Serialize()
+	var fence = strings.Repeat("`", max(3, longestRun(code, '`')+1))
+	var r = append([]byte(fence+n.attr("lang")+"\n"), code...)
+	if len(code) > 0 && code[len(code)-1] != '\n' {
+		r = append(r, '\n')
+	}
+	return append(r, []byte(fence)...)
+}
+
+// list items are indented by four spaces, the first line carrying the
+// marker. Items of loose lists are seperated by an empty line, as are the
+// terms of definition lists from the preceding definition.
+func list(n *Node) []byte {
+	var r = []byte{}
+	var flags = n.flags("flags")
+	for i, c := range n.children {
+		var marker string
+		var itemFlags = c.flags("flags")
+		switch {
+		case flags&b.LIST_TYPE_DEFINITION != 0 && itemFlags&b.LIST_TYPE_TERM != 0:
+			marker = ""
+		case flags&b.LIST_TYPE_DEFINITION != 0:
+			marker = ":   "
+		case flags&b.LIST_TYPE_ORDERED != 0:
+			marker = strconv.Itoa(i+1) + "." + strings.Repeat(" ", max(1, 3-len(strconv.Itoa(i+1))))
+		default:
+			marker = "-   "
+		}
+		if i > 0 && itemFlags&(b.LIST_ITEM_CONTAINS_BLOCK|b.LIST_TYPE_TERM) != 0 {
+			r = append(r, '\n')
+		}
+		r = append(r, indent(item(c), marker, "    ")...)
+	}
+	return r
+}
+
+// the content of list-, or footnote items either consists of inline content,
+// nested blocks, or both. Consecutive inline nodes are rendered as one line.
+func item(n *Node) []byte {
+	var r = [][]byte{}
+	var span = []*Node{}
+	var flush = func() {
+		if len(span) > 0 {
+			if v := bytes.TrimRight(inline(span...), " \n"); len(v) > 0 {
+				r = append(r, append(v, '\n'))
+			}
+			span = []*Node{}
+		}
+	}
+	for _, c := range n.children {
+		if c.flag&t.NODE_BLOCK != 0 {
+			flush()
+			r = append(r, block(c))
+		} else {
+			span = append(span, c)
+		}
+	}
+	flush()
+	if n.flags("flags")&b.LIST_ITEM_CONTAINS_BLOCK != 0 {
+		return bytes.Join(r, []byte("\n"))
+	}
+	return bytes.Join(r, nil)
+}
+
+// tables are written with leading and trailing pipes. The alignment of each
+// column is taken from the tables attributes, keyed by column index.
+func table(n *Node) []byte {
+	var r = []byte{}
+	var head = true
+	for _, row := range n.children {
+		if head && (len(row.children) == 0 || row.children[0].kind != t.TOKEN_TABLE_HEADER_CELL) {
+			r = append(r, alignment(n)...)
+			head = false
+		}
+		r = append(r, '|')
+		for _, cell := range row.children {
+			r = append(r, ' ')
+			r = append(r, bytes.Replace(bytes.TrimSpace(inline(cell.children...)), []byte("|"), []byte("\\|"), -1)...)
+			r = append(r, []byte(" |")...)
+		}
+		r = append(r, '\n')
+	}
+	if head {
+		r = append(r, alignment(n)...)
+	}
+	return r
+}
+
+// the delimiter row between header and body encodes the column alignment
+func alignment(n *Node) []byte {
+	var r = []byte{'|'}
+	for _, p := range n.parm {
+		var flags, _ = strconv.Atoi(p.Value().String())
+		switch flags {
+		case b.TABLE_ALIGNMENT_LEFT:
+			r = append(r, []byte(" :--- |")...)
+		case b.TABLE_ALIGNMENT_RIGHT:
+			r = append(r, []byte(" ---: |")...)
+		case b.TABLE_ALIGNMENT_CENTER:
+			r = append(r, []byte(" :---: |")...)
+		default:
+			r = append(r, []byte(" --- |")...)
+		}
+	}
+	return append(r, '\n')
+}
+
+// footnotes get collected at the end of the document, each one introduced by
+// its name.
+func footnotes(n *Node) []byte {
+	var r = [][]byte{}
+	for _, c := range n.children {
+		var marker = "[^" + c.attr("name") + "]: "
+		r = append(r, indent(item(c), marker, "    "))
+	}
+	return bytes.Join(r, []byte("\n"))
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// SPAN LEVEL
+///
+// inline renders a sequence of span level nodes
+func inline(n ...*Node) []byte {
+	var r = &bytes.Buffer{}
+	for _, c := range n {
+		span(r, c)
+	}
+	return r.Bytes()
+}
+func span(r *bytes.Buffer, n *Node) {
+	switch n.kind {
+	case t.TOKEN_NORMAL_TEXT:
+		escape(r, n.val.Serialize())
+	case t.TOKEN_EMPHASIS:
+		enclose(r, "*", n.children)
+	case t.TOKEN_DOUBLE_EMPHASIS:
+		enclose(r, "**", n.children)
+	case t.TOKEN_TRIPLE_EMPHASIS:
+		enclose(r, "***", n.children)
+	case t.TOKEN_STRIKE_THROUGH:
+		enclose(r, "~~", n.children)
+	case t.TOKEN_CODE_SPAN:
+		var code = n.val.Serialize()
+		var ticks = strings.Repeat("`", longestRun(code, '`')+1)
+		if bytes.HasPrefix(code, []byte("`")) || bytes.HasSuffix(code, []byte("`")) {
+			code = append(append([]byte(" "), code...), ' ')
+		}
+		r.WriteString(ticks)
+		r.Write(code)
+		r.WriteString(ticks)
+	case t.TOKEN_LINK:
+		r.WriteByte('[')
+		r.Write(inline(n.children...))
+		r.WriteString("](" + destination(n) + ")")
+	case t.TOKEN_IMAGE:
+		r.WriteString("![")
+		escape(r, []byte(n.attr("alt")))
+		r.WriteString("](" + destination(n) + ")")
+	case t.TOKEN_AUTO_LINK:
+		r.WriteString("<" + n.attr("link") + ">")
+	case t.TOKEN_LINE_BREAK:
+		r.WriteString("  \n")
+	case t.TOKEN_FOOTNOTE_REF:
+		r.WriteString("[^")
+		r.Write(n.val.Serialize())
+		r.WriteString("]")
+	case t.TOKEN_RAW_HTML_TAG, t.TOKEN_ENTITY:
+		r.Write(n.val.Serialize())
+	default:
+		r.Write(inline(n.children...))
+	}
+}
+
+// enclose renders the children of a node between delimiters
+func enclose(r *bytes.Buffer, delim string, n []*Node) {
+	r.WriteString(delim)
+	r.Write(inline(n...))
+	r.WriteString(delim)
+}
+
+// link destination followed by the title, if there is one
+func destination(n *Node) string {
+	if title := n.attr("title"); title != "" {
+		return n.attr("link") + " \"" + strings.Replace(title, "\"", "\\\"", -1) + "\""
+	}
+	return n.attr("link")
+}
+
+// escape writes plain text, escaping all characters that would otherwise
+// start inline markup, or a block element at the beginning of a line.
+func escape(r *bytes.Buffer, text []byte) {
+	for i, c := range text {
+		var lineStart = (r.Len() == 0 || r.Bytes()[r.Len()-1] == '\n')
+		switch {
+		case bytes.IndexByte([]byte("\\`*_[]<~"), c) >= 0:
+			r.WriteByte('\\')
+		case lineStart && bytes.IndexByte([]byte("#>+-=:%"), c) >= 0:
+			r.WriteByte('\\')
+		case lineStart && c >= '0' && c <= '9' && orderedMarker(text[i:]):
+			r.Write(text[i : i+bytes.IndexByte(text[i:], '.')])
+			r.WriteByte('\\')
+			escape(r, text[i+bytes.IndexByte(text[i:], '.'):])
+			return
+		}
+		r.WriteByte(c)
+	}
+}
+
+// determines if the text starts with digits, followed by a dot and a space,
+// or the end of the text
+func orderedMarker(text []byte) bool {
+	for i, c := range text {
+		if c == '.' {
+			return i > 0 && (i == len(text)-1 || text[i+1] == ' ')
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return false
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// HELPERS
+///
+// indent prefixes the first line of text by first, all following lines that
+// are not empty by rest.
+func indent(text []byte, first, rest string) []byte {
+	var r = []byte{}
+	var lines = bytes.Split(bytes.TrimRight(text, "\n"), []byte("\n"))
+	for i, l := range lines {
+		switch {
+		case i == 0:
+			r = append(r, []byte(first)...)
+		case len(l) > 0:
+			r = append(r, []byte(rest)...)
+		default:
+			r = append(r, []byte(strings.TrimRight(rest, " "))...)
+		}
+		r = append(r, l...)
+		r = append(r, '\n')
+	}
+	return r
+}
+
+// longest sequence of the passed character contained in text
+func longestRun(text []byte, c byte) int {
+	var l, n int
+	for _, x := range text {
+		if x == c {
+			n++
+			if n > l {
+				l = n
+			}
+		} else {
+			n = 0
+		}
+	}
+	return l
+}
@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	t "github.com/JoergReinhardt/blackfriday/types"
+	b "github.com/russross/blackfriday"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const extensions = b.EXTENSION_TABLES |
+	b.EXTENSION_FENCED_CODE |
+	b.EXTENSION_FOOTNOTES |
+	b.EXTENSION_DEFINITION_LISTS |
+	b.EXTENSION_STRIKETHROUGH |
+	b.EXTENSION_HEADER_IDS
+
+// tokens of the document in the order they are emitted by the renderer, as
+// one line each. Consecutive text tokens get merged, since the parser splits
+// text at escaped characters.
+func tokenLines(md []byte) []string {
+	var r = []string{}
+	var text = ""
+	var flush = func() {
+		if text != "" {
+			r = append(r, t.TOKEN_NORMAL_TEXT.String()+" "+text)
+			text = ""
+		}
+	}
+	Parse(md, extensions).Walk(func(e t.NodeType, n *Node) bool {
+		if n.kind == t.TOKEN_NORMAL_TEXT {
+			if e == t.NODE_OPEN {
+				text = text + n.val.String()
+			}
+			return true
+		}
+		flush()
+		if e != t.NODE_OPEN || n.flag&t.NODE_ROOT != 0 {
+			return true
+		}
+		var l = n.kind.String()
+		for _, p := range n.parm {
+			l = l + " " + p.Key().String() + "=" + p.Value().String()
+		}
+		if n.kind&(t.TOKEN_LOW_LEVEL|t.TOKEN_SPAN) != 0 {
+			l = l + " " + n.val.String()
+		}
+		r = append(r, l)
+		return true
+	})
+	flush()
+	return r
+}
+
+func TestWriter(test *testing.T) {
+	files, err := filepath.Glob("testdata/*.md")
+	if err != nil {
+		test.Fatal(err)
+	}
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		if err != nil {
+			test.Fatal(err)
+		}
+		var out = Markdown(Parse(src, extensions))
+		var golden = strings.TrimSuffix(file, ".md") + ".golden"
+		if *update {
+			if err := ioutil.WriteFile(golden, out, 0644); err != nil {
+				test.Fatal(err)
+			}
+		}
+		expect, err := ioutil.ReadFile(golden)
+		if err != nil {
+			test.Fatal(err)
+		}
+		if !bytes.Equal(out, expect) {
+			test.Errorf("%s: written markdown differs from golden file:\n%s", file, out)
+		}
+		if again := Markdown(Parse(out, extensions)); !bytes.Equal(out, again) {
+			test.Errorf("%s: writing the written markdown again yields:\n%s", file, again)
+		}
+		var before = strings.Join(tokenLines(src), "\n")
+		var after = strings.Join(tokenLines(out), "\n")
+		if before != after {
+			test.Errorf("%s: tokens differ after round trip\nsource:\n%s\nwritten:\n%s",
+				file, before, after)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+)
+
+/////////////////////////////////////////////////////////////////////////
+//// ARITHMETIC DISPATCH
+///
+// package level operations take evaluables of any type and decide what to do,
+// based on the types of their operands:
+//
+//   - numbers get promoted along the tower bool → integer → decimal → ratio
+//     → float, to the highest ranking type among both operands. The
+//     operation is performed exactly and its result returned as the narrowest
+//     type, that represents it without loss. Integral results of naturals and
+//     ratios become Integer, all others Ratio. Decimals stay Decimal, unless
+//     a quotient has no finite decimal representation. Results involving a
+//     float are rounded to the precision of the float operands and stay
+//     Float.
+//   - text and bytes get concatenated by Add. If either operand is text, the
+//     result is text, otherwise bytes.
+//   - matrices of matching shape get added, subtracted and multiplied
+//     exactly. Numbers multiply all elements of a matrix, or divide them.
+//   - collections get the second operand appended by Add. If it's a
+//     collection itself, its values are appended.
+//
+// All other combinations return an error. Operands are left untouched.
+type arithOp uint8
+
+const (
+	opAdd arithOp = iota
+	opSub
+	opMul
+	opQuo
+	opCmp
+)
+
+var opNames = [...]string{"add", "subtract", "multiply", "divide", "compare"}
+
+func Add(a, b Evaluable) (Evaluable, error) { return arith(opAdd, a, b) }
+func Sub(a, b Evaluable) (Evaluable, error) { return arith(opSub, a, b) }
+func Mul(a, b Evaluable) (Evaluable, error) { return arith(opMul, a, b) }
+func Quo(a, b Evaluable) (Evaluable, error) { return arith(opQuo, a, b) }
+
+// Cmp returns an Integer of -1, 0, or +1, if a is less than, equal to, or
+// greater than b. Symbolic values compare bytewise.
+func Cmp(a, b Evaluable) (Evaluable, error) { return arith(opCmp, a, b) }
+
+func arith(op arithOp, a, b Evaluable) (Evaluable, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("types: can not %s nil", opNames[op])
+	}
+	var ta, tb = scalarType(a), scalarType(b)
+	switch {
+	case ta&NUMERIC != 0 && tb&NUMERIC != 0:
+		return arithNumeric(op, a, b)
+	case ta&SYMBOLIC != 0 && tb&SYMBOLIC != 0:
+		return arithSymbolic(op, a, b)
+	case ta == MATRIX || tb == MATRIX:
+		return arithMatrix(op, a, b)
+	case ta&(COLLECTED|MAP) != 0 && op == opAdd:
+		if r, ok := appendCollection(a, b); ok {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("types: can not %s %s and %s", opNames[op], ta, tb)
+}
+
+// rank of a numeric type within the tower
+func numericRank(t ValueType) int {
+	switch {
+	case t&FLOAT != 0:
+		return 4
+	case t&RATIONAL != 0:
+		return 3
+	case t&DECIMAL != 0:
+		return 2
+	}
+	return 1
+}
+
+func arithNumeric(op arithOp, a, b Evaluable) (Evaluable, error) {
+	var rank = numericRank(scalarType(a))
+	if r := numericRank(scalarType(b)); r > rank {
+		rank = r
+	}
+	switch {
+	case rank == 4:
+		return arithFloat(op, a, b)
+	case rank == 2 && op != opQuo && op != opCmp:
+		return arithDecimal(op, a, b)
+	}
+	var x, okx = bigRatOf(a)
+	var y, oky = bigRatOf(b)
+	if !okx || !oky {
+		return nil, fmt.Errorf("types: can not %s %s and %s",
+			opNames[op], scalarType(a), scalarType(b))
+	}
+	var r = ratPool.Get().(*big.Rat)
+	switch op {
+	case opAdd:
+		r.Add(x, y)
+	case opSub:
+		r.Sub(x, y)
+	case opMul:
+		r.Mul(x, y)
+	case opQuo:
+		if y.Sign() == 0 {
+			discardRat(r)
+			return nil, fmt.Errorf("types: division by zero")
+		}
+		r.Quo(x, y)
+	case opCmp:
+		discardRat(r)
+		return NewInteger(int64(x.Cmp(y))), nil
+	}
+	if rank == 2 {
+		if d, ok := exactDecimal(r); ok {
+			discardRat(r)
+			return d, nil
+		}
+	}
+	return narrow(r), nil
+}
+
+// decimals and naturals get added, subtracted and multiplied as decimals,
+// naturals having a scale of zero
+func arithDecimal(op arithOp, a, b Evaluable) (Evaluable, error) {
+	var x, y = promote(a, DECIMAL).(Decimal), promote(b, DECIMAL).(Decimal)
+	switch op {
+	case opAdd:
+		return x.Add(y), nil
+	case opSub:
+		return x.Sub(y), nil
+	}
+	return x.Mul(y), nil
+}
+
+// narrow returns the result as the narrowest type, that keeps its value
+func narrow(r *big.Rat) Evaluable {
+	if r.IsInt() {
+		defer discardRat(r)
+		return wrap(intPool.Get().(*big.Int).Set(r.Num())).(val).Integer()
+	}
+	return wrap(r).(Ratio)
+}
+
+// at least one operand is a float. Finite values compare exactly, all other
+// operations round to the larger precision of the float operands, using the
+// rounding mode of the first one.
+func arithFloat(op arithOp, a, b Evaluable) (r Evaluable, err error) {
+	var x, okx = bigFloatOf(a)
+	var y, oky = bigFloatOf(b)
+	if !okx || !oky {
+		return nil, fmt.Errorf("types: can not %s %s and %s",
+			opNames[op], scalarType(a), scalarType(b))
+	}
+	if op == opCmp {
+		rx, okx := bigRatOf(a)
+		ry, oky := bigRatOf(b)
+		if okx && oky {
+			return NewInteger(int64(rx.Cmp(ry))), nil
+		}
+		return NewInteger(int64(x.Cmp(y))), nil
+	}
+	if op == opQuo && y.Sign() == 0 {
+		return nil, fmt.Errorf("types: division by zero")
+	}
+	var prec, mode = uint(0), big.ToNearestEven
+	for _, v := range []Evaluable{b, a} {
+		if f, ok := v.(Float); ok {
+			if f.Prec() > prec {
+				prec = f.Prec()
+			}
+			mode = f.Mode()
+		}
+	}
+	// infinite operands may yield NaN, which big Float reports by panic
+	defer func() {
+		if e := recover(); e != nil {
+			n, ok := e.(big.ErrNaN)
+			if !ok {
+				panic(e)
+			}
+			r, err = nil, fmt.Errorf("types: %s", n.Error())
+		}
+	}()
+	var z = newFloat(prec, mode)
+	switch op {
+	case opAdd:
+		z.Add(x, y)
+	case opSub:
+		z.Sub(x, y)
+	case opMul:
+		z.Mul(x, y)
+	case opQuo:
+		z.Quo(x, y)
+	}
+	return wrap(z).(Float), nil
+}
+
+func arithSymbolic(op arithOp, a, b Evaluable) (Evaluable, error) {
+	switch op {
+	case opAdd:
+		var s = append(append([]byte{}, a.Serialize()...), b.Serialize()...)
+		if a.Type() == TEXT || b.Type() == TEXT {
+			return textFromBytes(s), nil
+		}
+		return NewBytes(s), nil
+	case opCmp:
+		return NewInteger(int64(bytes.Compare(a.Serialize(), b.Serialize()))), nil
+	}
+	return nil, fmt.Errorf("types: can not %s %s and %s", opNames[op], a.Type(), b.Type())
+}
+
+// appends b to a copy of the collection a. Collections passed as b get
+// flattened by one level.
+func appendCollection(a, b Evaluable) (Evaluable, bool) {
+	var v = []Evaluable{b}
+	if c, ok := b.(Collected); ok {
+		v = c.Values()
+	}
+	var c, ok = a.(Collected)
+	if !ok {
+		return nil, false
+	}
+	switch c := copyCollection(c).(type) {
+	case Listed:
+		return c.Add(v...), true
+	case Stacked:
+		return c.Add(v...), true
+	case Mapped:
+		return c.Add(v...), true
+	case DeDublicated:
+		return c.Add(v...), true
+	}
+	return nil, false
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func ratioOf(a, b int64) Evaluable { return wrap(big.NewRat(a, b)) }
+
+var arithTests = []struct {
+	opStr string
+	op    func(a, b Evaluable) (Evaluable, error)
+	args  func() [2]Evaluable
+	typ   ValueType
+	exp   string
+	err   bool
+}{
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{NewInteger(2), NewInteger(3)} }, INTEGER, "5", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{Value(true), NewInteger(3)} }, INTEGER, "4", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{Value(2), NewInteger(3)} }, INTEGER, "5", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{NewInteger(1), ratioOf(1, 2)} }, RATIONAL, "3/2", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{ratioOf(1, 2), ratioOf(1, 2)} }, INTEGER, "1", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{promote(Value(0.25), FLOAT), NewInteger(1)} }, FLOAT, "1.25", false},
+	{"Sub", Sub, func() [2]Evaluable { return [2]Evaluable{NewInteger(2), NewInteger(5)} }, INTEGER, "-3", false},
+	{"Sub", Sub, func() [2]Evaluable { return [2]Evaluable{ratioOf(3, 4), ratioOf(1, 4)} }, RATIONAL, "1/2", false},
+	{"Mul", Mul, func() [2]Evaluable { return [2]Evaluable{ratioOf(2, 3), NewInteger(3)} }, INTEGER, "2", false},
+	{"Mul", Mul, func() [2]Evaluable { return [2]Evaluable{NewInteger(-4), NewInteger(3)} }, INTEGER, "-12", false},
+	{"Quo", Quo, func() [2]Evaluable { return [2]Evaluable{NewInteger(6), NewInteger(3)} }, INTEGER, "2", false},
+	{"Quo", Quo, func() [2]Evaluable { return [2]Evaluable{NewInteger(1), NewInteger(3)} }, RATIONAL, "1/3", false},
+	{"Quo", Quo, func() [2]Evaluable { return [2]Evaluable{NewInteger(1), NewInteger(0)} }, EMPTY, "", true},
+	{"Cmp", Cmp, func() [2]Evaluable { return [2]Evaluable{NewInteger(1), ratioOf(1, 2)} }, INTEGER, "1", false},
+	{"Cmp", Cmp, func() [2]Evaluable { return [2]Evaluable{ratioOf(1, 3), promote(Value(0.5), FLOAT)} }, INTEGER, "-1", false},
+	{"Cmp", Cmp, func() [2]Evaluable { return [2]Evaluable{NewText("a"), NewText("b")} }, INTEGER, "-1", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{NewText("ab"), NewText("cd")} }, TEXT, "abcd", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{NewBytes([]byte("ab")), NewText("cd")} }, TEXT, "abcd", false},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{NewBytes([]byte("ab")), NewBytes([]byte("cd"))} }, BYTES, "abcd", false},
+	{"Sub", Sub, func() [2]Evaluable { return [2]Evaluable{NewText("ab"), NewText("cd")} }, EMPTY, "", true},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{NewText("ab"), NewInteger(1)} }, EMPTY, "", true},
+	{"Add", Add, func() [2]Evaluable { return [2]Evaluable{nil, NewInteger(1)} }, EMPTY, "", true},
+	{"Add", Add, func() [2]Evaluable {
+		return [2]Evaluable{NewArrayList(NewInteger(1)), NewInteger(2)}
+	}, LIST, "[1 2]", false},
+	{"Add", Add, func() [2]Evaluable {
+		return [2]Evaluable{NewArrayList(NewInteger(1)), NewArrayList(NewInteger(2), NewInteger(3))}
+	}, LIST, "[1 2 3]", false},
+	{"Mul", Mul, func() [2]Evaluable {
+		return [2]Evaluable{NewArrayList(NewInteger(1)), NewInteger(2)}
+	}, EMPTY, "", true},
+}
+
+func TestArith(t *testing.T) {
+	for n, test := range arithTests {
+		var args = test.args()
+		r, err := test.op(args[0], args[1])
+		if test.err {
+			if err == nil {
+				(*t).Fail()
+				(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s expected an error, got: %v", n, test.opStr, r))
+			}
+			continue
+		}
+		if err != nil {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s returned error: %s", n, test.opStr, err))
+			continue
+		}
+		var got = r.String()
+		if l, ok := r.(Listed); ok {
+			got = fmt.Sprint(l.Interfaces())
+		}
+		if r.Type() != test.typ || got != test.exp {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %s %s expected: %s %s",
+				n, test.opStr, r.Type(), got, test.typ, test.exp))
+		}
+	}
+}
+
+// adding to a collection returns a grown copy, the operand keeps its size
+var operandTests = []struct {
+	opStr string
+	op    func() Collected
+}{
+	{"ArrayList", func() Collected { return NewArrayList(NewInteger(1), NewInteger(2)) }},
+	{"DLList", func() Collected { return newDLLList().Add(NewInteger(1), NewInteger(2)) }},
+	{"SLList", func() Collected { return newSLLList().Add(NewInteger(1), NewInteger(2)) }},
+	{"ArrayStack", func() Collected { return newArraystack().Add(NewInteger(1), NewInteger(2)) }},
+	{"LinkedStack", func() Collected { return newLinkedStack().Add(NewInteger(1), NewInteger(2)) }},
+	{"HashMap", func() Collected {
+		return newHashMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+	}},
+	{"HashBidiMap", func() Collected {
+		return newHashBidiMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+	}},
+	{"TreeMap", func() Collected {
+		return newTreeMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+	}},
+	{"TreeBidiMap", func() Collected {
+		return newTreeBidiMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+	}},
+	{"HashSet", func() Collected { return newHashSet(NewInteger(1), NewInteger(2)) }},
+	{"TreeSet", func() Collected { return newTreeSet().Add(NewInteger(1), NewInteger(2)) }},
+}
+
+func TestArithOperands(t *testing.T) {
+	for n, test := range operandTests {
+		var c = test.op()
+		var before = c.Values()
+		r, err := Add(c, Value(NewText("x"), NewInteger(3)))
+		var got = fmt.Sprint(c.Size(), " ", sameSequence(before, c.Values()), " ")
+		if err == nil {
+			got = got + fmt.Sprint(r.(Collected).Size())
+		}
+		if got != "2 true 3" {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %q expected: %q", n, test.opStr, got, "2 true 3"))
+		}
+	}
+}
@@ -59,51 +59,32 @@ func (u Bool) SetBool(x Bool) Bool {
 		return Value(-1).(val).Bool()
 	}
 }
-func (u Bool) SetBoolSlice(x ...Bool) (r BitFlag) {
-	var res *big.Int
-	// range over slice of Bools
+// encodes the slice as flag, the bool at index i sets the bit at position i
+func (u Bool) SetBoolSlice(x ...Bool) BitFlag {
+	var b = []bool{}
 	for _, i := range x {
-		// use native to get a true/false value as the if condition
-		if i.Native() { // left shift either uint one…
-			res = val(r).lsh(r(), 1)
-		} else { // or uint zero to preexisting uint and overwrite it
-			// with the result
-			res = val(r).lsh(r(), 0)
-		}
+		b = append(b, i.Native())
 	}
-	return wrap(res).(val).bitFlag()
+	return boolsToFlag(b)
 }
 func (u Bool) SetBoolNative(x bool) (r Bool) {
 	if x {
-		r = wrap(intPool.Get().(val).setInt64(+1)).(val).Bool()
+		r = wrap(intPool.Get().(*big.Int).SetInt64(+1)).(val).Bool()
 	} else {
-		r = wrap(intPool.Get().(val).setInt64(-1)).(val).Bool()
+		r = wrap(intPool.Get().(*big.Int).SetInt64(-1)).(val).Bool()
 	}
 	return r
 }
-func (u Bool) SetBoolSliceNative(x ...bool) (r BitFlag) {
-	var res *big.Int
-	// range over slice of Bools
-	for _, i := range x {
-		// use native to get a true/false value as the if condition
-		if i { // left shift either uint one…
-			res = val(r).lsh(r(), 1)
-		} else { // or uint zero to preexisting uint and overwrite it
-			// with the result
-			res = val(r).lsh(r(), 0)
-		}
-	}
-	return wrap(res).(val).bitFlag()
-}
+func (u Bool) SetBoolSliceNative(x ...bool) BitFlag { return boolsToFlag(x) }
 func (u Bool) SetInteger(x Integer) Bool {
 	// discard parameter and old version
 	defer discardInt(x(), u())
 	// pre allocate return value
 	var res Bool
 	if x().Int64() > 0 {
-		res = wrap(intPool.Get().(val).setInt64(1)).(val).Bool()
+		res = wrap(intPool.Get().(*big.Int).SetInt64(1)).(val).Bool()
 	} else {
-		res = wrap(intPool.Get().(val).setInt64(-1)).(val).Bool()
+		res = wrap(intPool.Get().(*big.Int).SetInt64(-1)).(val).Bool()
 	}
 	return res
 }
@@ -113,9 +94,9 @@ func (u Bool) SetIntegerNative(x int64) Bool {
 	// pre allocate return value
 	var res Bool
 	if x > 0 {
-		res = wrap(intPool.Get().(val).setInt64(1)).(val).Bool()
+		res = wrap(intPool.Get().(*big.Int).SetInt64(1)).(val).Bool()
 	} else {
-		res = wrap(intPool.Get().(val).setInt64(-1)).(val).Bool()
+		res = wrap(intPool.Get().(*big.Int).SetInt64(-1)).(val).Bool()
 	}
 	return res
 }
@@ -126,5 +107,5 @@ func (u Bool) SetUintNative(x uint64) BitFlag {
 	// discard parameter and old version
 	defer discardInt(u())
 	// pre allocate return value
-	return wrap(intPool.Get().(val).setUint64(x)).(val).bitFlag()
+	return wrap(intPool.Get().(*big.Int).SetUint64(x)).(val).bitFlag()
 }
@@ -0,0 +1,152 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+//////////////////////////////////////////////////////////////////////////
+//// BOOL CODEC
+///
+// a sequence of booleans can be encoded in any of the bool types. The bool at
+// index i of the sequence corresponds to the bit at position i of all flag
+// encodings, LISTED encodes each bool as Bool and SIGNED as Integer of either
+// +1 (true), or -1 (false). Zero is the undetermined third state of a signed
+// list and can not be decoded to a bool.
+//
+// Flags don't keep track of their length, trailing false values are lost,
+// when a sequence gets encoded as flag. Conversions that would loose set bits,
+// or don't fit the width of the target encoding, return an error instead.
+
+// widths and valid bits of the fixed size flag encodings. Bit zero is no
+// value type, EMPTY is the absence of all bits.
+var boolWidths = map[BoolType]struct {
+	width int
+	mask  uint64
+}{
+	NATIVE:     {1, 1},
+	UINT_FLAG:  {64, 1<<64 - 1},
+	VAL_TYPE:   {big.NewInt(MASK).BitLen(), uint64(MASK &^ 1)},
+	TOKEN_TYPE: {big.NewInt(int64(TOKEN_MASK)).BitLen(), uint64(TOKEN_MASK)},
+	NODE_TYPE:  {big.NewInt(int64(NODE_MASK)).BitLen(), uint64(NODE_MASK)},
+}
+
+// EncodeBools encodes the sequence of booleans according to the bool type
+func EncodeBools(t BoolType, b ...bool) (Evaluable, error) {
+	switch t {
+	case LISTED:
+		var r = []Evaluable{}
+		for _, x := range b {
+			r = append(r, Value(x))
+		}
+		return NewArrayList(r...), nil
+	case SIGNED:
+		var r = []Evaluable{}
+		for _, x := range b {
+			if x {
+				r = append(r, NewInteger(+1))
+			} else {
+				r = append(r, NewInteger(-1))
+			}
+		}
+		return NewArrayList(r...), nil
+	case BIT_FLAG:
+		return boolsToFlag(b), nil
+	}
+	var w, ok = boolWidths[t]
+	if !ok {
+		return nil, fmt.Errorf("types: unknown bool type %s", t)
+	}
+	if len(b) > w.width {
+		return nil, fmt.Errorf("types: %d bools don't fit %s of width %d",
+			len(b), t, w.width)
+	}
+	var u uint64
+	for i, x := range b {
+		if x {
+			u |= 1 << uint(i)
+		}
+	}
+	if u&^w.mask != 0 {
+		return nil, fmt.Errorf("types: bits %b are no valid %s", u&^w.mask, t)
+	}
+	switch t {
+	case NATIVE:
+		return Value(u == 1), nil
+	case UINT_FLAG:
+		return NewUint(u), nil
+	case VAL_TYPE:
+		return ValueType(u), nil
+	case TOKEN_TYPE:
+		return TokenType(u), nil
+	}
+	return NodeType(u), nil
+}
+
+// DecodeBools returns the sequence of booleans encoded by the value. The
+// encoding is determined by the values type.
+func DecodeBools(v Evaluable) ([]bool, error) {
+	switch x := v.(type) {
+	case Bool:
+		return []bool{x.Native()}, nil
+	case BitFlag:
+		return bitsToBools(x()), nil
+	case Uint:
+		return bitsToBools(x()), nil
+	case ValueType:
+		return bitsToBools(new(big.Int).SetUint64(uint64(x))), nil
+	case TokenType:
+		return bitsToBools(new(big.Int).SetUint64(uint64(x))), nil
+	case NodeType:
+		return bitsToBools(new(big.Int).SetUint64(uint64(x))), nil
+	case Listed:
+		var r = []bool{}
+		for n, e := range x.Values() {
+			if b, ok := e.(Bool); ok {
+				r = append(r, b.Native())
+				continue
+			}
+			var i, ok = bigIntOf(e)
+			switch {
+			case ok && i.Cmp(big.NewInt(+1)) == 0:
+				r = append(r, true)
+			case ok && i.Cmp(big.NewInt(-1)) == 0:
+				r = append(r, false)
+			default:
+				return nil, fmt.Errorf("types: element %d (%s) is no bool", n, e)
+			}
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("types: %s of type %s encodes no bools", v, v.Type())
+}
+
+// ConvertBools decodes the value and encodes the contained booleans according
+// to the bool type.
+func ConvertBools(v Evaluable, t BoolType) (Evaluable, error) {
+	var b, err = DecodeBools(v)
+	if err != nil {
+		return nil, err
+	}
+	return EncodeBools(t, b...)
+}
+
+// the bool at index i sets the bit at position i
+func boolsToFlag(b []bool) BitFlag {
+	var r = newFlag()
+	for i, x := range b {
+		if x {
+			r().SetBit(r(), i, 1)
+		}
+	}
+	return r
+}
+
+// one bool per bit up to the most significant set bit
+func bitsToBools(i *big.Int) []bool {
+	var r = make([]bool, i.BitLen())
+	for n := range r {
+		r[n] = i.Bit(n) == 1
+	}
+	return r
+}
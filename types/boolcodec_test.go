@@ -0,0 +1,138 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+// converts the value to each of the passed encodings in turn, returns nil on
+// the first error
+func boolChain(v Evaluable, ts ...BoolType) Evaluable {
+	var err error
+	for _, t := range ts {
+		if v, err = ConvertBools(v, t); err != nil {
+			return nil
+		}
+	}
+	return v
+}
+
+// n bools, all set to true
+func trueBools(n int) []bool {
+	var r = make([]bool, n)
+	for i := range r {
+		r[i] = true
+	}
+	return r
+}
+
+// bools get encoded to the expected value and decode to the expected bools
+var boolRoundTripTests = []struct {
+	t   BoolType
+	b   []bool
+	typ ValueType
+	exp interface{}
+	dec []bool
+}{
+	{NATIVE, []bool{true}, BOOL, true, []bool{true}},
+	{NATIVE, []bool{}, BOOL, false, []bool{false}},
+	{LISTED, []bool{true, false, false}, LIST, []interface{}{true, false, false}, []bool{true, false, false}},
+	{SIGNED, []bool{false, true, false}, LIST, []interface{}{-1, 1, -1}, []bool{false, true, false}},
+	{BIT_FLAG, []bool{true, false, true}, FLAG, bits{0, 2}, []bool{true, false, true}},
+	{BIT_FLAG, []bool{false, true, false}, FLAG, bits{1}, []bool{false, true}}, // trailing false
+	{UINT_FLAG, []bool{true, true, false, true}, UINT, uint(11), []bool{true, true, false, true}},
+	{UINT_FLAG, trueBools(64), UINT, uint64(1<<64 - 1), trueBools(64)},
+	{VAL_TYPE, []bool{false, true, false, true}, FLAG, BOOL | INTEGER, []bool{false, true, false, true}},
+	{NODE_TYPE, []bool{false, true, true}, FLAG, NODE_OPEN | NODE_CLOSE, []bool{false, true, true}},
+}
+
+func TestBoolRoundTrip(t *testing.T) {
+	for n, test := range boolRoundTripTests {
+		var opStr = fmt.Sprintf("EncodeBools(%d, %v)", test.t, test.b)
+		e, err := EncodeBools(test.t, test.b...)
+		if err != nil {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s error: %s", n, opStr, err))
+			continue
+		}
+		testValue(t, n, opStr, e, test.typ, nativeValue(test.exp))
+		if d, err := DecodeBools(e); err != nil || fmt.Sprint(d) != fmt.Sprint(test.dec) {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s decoded: %v %v expected: %v", n, opStr, d, err, test.dec))
+		}
+	}
+}
+
+// invalid encodings and conversions return no value, but an error
+var boolErrorTests = []struct {
+	opStr string
+	op    func() (Evaluable, error)
+}{
+	{"NATIVE too long", func() (Evaluable, error) { return EncodeBools(NATIVE, true, false) }},
+	{"SIGNED zero", func() (Evaluable, error) {
+		_, err := DecodeBools(NewArrayList(NewInteger(1), NewInteger(0)))
+		return nil, err
+	}},
+	{"UINT_FLAG 65", func() (Evaluable, error) { return EncodeBools(UINT_FLAG, trueBools(65)...) }},
+	{"VAL_TYPE too wide", func() (Evaluable, error) {
+		return EncodeBools(VAL_TYPE, trueBools(boolWidths[VAL_TYPE].width+1)...)
+	}},
+	{"VAL_TYPE bit zero", func() (Evaluable, error) { return EncodeBools(VAL_TYPE, true) }},
+	{"VAL_TYPE bit zero converted", func() (Evaluable, error) { return ConvertBools(NewFlag(0, 2), VAL_TYPE) }},
+	{"TOKEN_TYPE bit zero", func() (Evaluable, error) { return EncodeBools(TOKEN_TYPE, true) }},
+	{"NODE_TYPE too wide", func() (Evaluable, error) { return ConvertBools(NewFlag(7), NODE_TYPE) }},
+	{"unknown", func() (Evaluable, error) { return EncodeBools(BoolType(3), true) }},
+	{"no bools", func() (Evaluable, error) { _, err := DecodeBools(NewText("x")); return nil, err }},
+	{"chain lossy", func() (Evaluable, error) {
+		v, err := ConvertBools(NewFlag(1, 20), LISTED)
+		if err != nil { // only the second conversion loses bits
+			return v, nil
+		}
+		return ConvertBools(v, NODE_TYPE)
+	}},
+}
+
+func TestBoolErrors(t *testing.T) {
+	for n, test := range boolErrorTests {
+		if v, err := test.op(); v != nil || err == nil {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %v %v expected an error", n, test.opStr, v, err))
+		}
+	}
+}
+
+// conversions between the encodings, expected flags are passed as positions
+// of the bits set
+var boolConvertTests = []struct {
+	opStr string
+	typ   ValueType
+	exp   interface{}
+	op    func() Evaluable
+}{
+	{"TOKEN_TYPE", FLAG, TOKEN_HEADER | TOKEN_EMPHASIS, func() Evaluable {
+		e, _ := ConvertBools(NewFlag(6, 21), TOKEN_TYPE)
+		return e.(TokenType)
+	}},
+	{"chain", FLAG, bits{1, 3}, func() Evaluable {
+		return boolChain(NewFlag(1, 3), LISTED, SIGNED, UINT_FLAG, VAL_TYPE, NODE_TYPE, BIT_FLAG)
+	}},
+	{"Flag Bool SIGNED", LIST, []interface{}{1, -1, 1}, func() Evaluable { return NewFlag(0, 2).Bool(SIGNED) }},
+	{"Flag Bool UINT_FLAG", UINT, uint(5), func() Evaluable { return NewFlag(0, 2).Bool(UINT_FLAG) }},
+	{"Flag Bool too wide", UINT, nil, func() Evaluable { return NewFlag(64).Bool(UINT_FLAG) }},
+	{"Flag Bool NATIVE", BOOL, true, func() Evaluable { return NewFlag(3).Bool(NATIVE) }},
+	{"SetBoolSlice", FLAG, bits{0, 2}, func() Evaluable {
+		var b = Value(true).(Bool)
+		return b.SetBoolSlice(b, Value(false).(Bool), b)
+	}},
+	{"SetBoolSliceNative", FLAG, bits{1}, func() Evaluable { return Value(true).(Bool).SetBoolSliceNative(false, true) }},
+	{"SetBoolNative", BOOL, false, func() Evaluable { // false is encoded as -1
+		return Value(Value(true).(Bool).SetBoolNative(false).Native())
+	}},
+	{"ValueType Value", FLAG, NUMERIC, func() Evaluable { return Value(NUMERIC) }},
+}
+
+func TestBoolConvert(t *testing.T) {
+	for n, test := range boolConvertTests {
+		testValue(t, n, test.opStr, test.op(), test.typ, nativeValue(test.exp))
+	}
+}
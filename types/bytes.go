@@ -1,7 +1,7 @@
 package types
 
 import (
-//"math/big"
+	"math/big"
 )
 
 /////////////////////////////////////////////////////////////////////////
@@ -75,16 +75,22 @@ func (b Bytes) SetTextNative(x string) Bytes {
 	// reuse.
 	defer discardInt(b())
 	// b is set to a native string by replacing it vit a new value instance
-	return Value(x).(val).Bytes()
+	return NewBytes([]byte(x))
 }
 func (b Bytes) AppendText(x Text) Bytes {
 	defer discardInt(x())
 	// since big Ints Append returns a byte slice, we need to allocate a
 	// complete new instance of an Evaluable using Value
-	return Value(b.String() + x.String()).(val).Bytes()
+	return NewBytes([]byte(b.String() + x.String()))
 }
 func (b Bytes) AppendTextNative(x string) Bytes {
 	// since big Ints Append returns a byte slice, we need to allocate a
 	// complete new instance of an Evaluable using Value
-	return Value(b.String() + x).(val).Bytes()
+	return NewBytes([]byte(b.String() + x))
+}
+
+// NewBytes allocates a fresh Bytes instance from a native byte slice. The
+// slice is copied into the enclosed big Int, so the caller may reuse it.
+func NewBytes(x []byte) Bytes {
+	return wrap(intPool.Get().(*big.Int).SetBytes(x)).(val).Bytes()
 }
@@ -13,7 +13,6 @@ import (
 	tbm "github.com/emirpasic/gods/maps/treebidimap"
 	tm "github.com/emirpasic/gods/maps/treemap"
 	cs "github.com/emirpasic/gods/sets"
-	hs "github.com/emirpasic/gods/sets/hashset"
 	ts "github.com/emirpasic/gods/sets/treeset"
 	csa "github.com/emirpasic/gods/stacks"
 	as "github.com/emirpasic/gods/stacks/arraystack"
@@ -37,12 +36,12 @@ var (
 	mapGen  mapPool  = mapPool{}
 )
 
-func newList() ArrayList { return func() *al.List { return listGen.New().(*al.List) } }
-func newMap() HashMap    { return func() *hm.Map { return mapGen.New().(*hm.Map) } }
+func newList() ArrayList { l := listGen.New().(*al.List); return func() *al.List { return l } }
+func newMap() HashMap    { m := mapGen.New().(*hm.Map); return func() *hm.Map { return m } }
 
 func init() {
 	listGen.New = func() interface{} { return new(al.List) }
-	mapGen.New = func() interface{} { return new(hm.Map) }
+	mapGen.New = func() interface{} { return hm.New() }
 }
 
 //////////////////////// FUNCTIONAL TYPES TO REPRESENT VALUES /////////////////////
@@ -64,7 +63,7 @@ type (
 	TreeBidiMap func() *tbm.Map
 	// SETS
 	TreeSet func() *ts.Set
-	HashSet func() *hs.Set
+	HashSet func() *hm.Map
 	// TREES
 	Heap     func() *ht.Heap
 	RedBlack func() *rbt.Tree
@@ -80,12 +79,27 @@ type (
 // boolean scalar, that considers the first bit for a value as well.
 
 //// FUNCTIONS COMMON TO ALL COLLECTIONS
-func evalCollection(c con.Container) Evaluable           { return Value(c) }
-func collectionSize(c con.Container) int                 { return c.(con.Container).Size() }
-func emptyCollection(c con.Container) bool               { return c.(con.Container).Empty() }
-func collectionValues(c con.Container) []Evaluable       { return valueSlice(c.(con.Container).Values()) }
-func collectionInterfaces(c con.Container) []interface{} { return c.(con.Container).Values() }
-func clearCollection(c con.Container) Collected          { c.(con.Container).Clear(); return c.(Collected) }
+// helpers are passed the public collection, as well as the container it
+// encloses, since the gods containers and the public collection interfaces
+// can not be asserted to one another (conflicting method signatures).
+func evalCollection(c Collected) Evaluable                   { return c }
+func collectionSize(c con.Container) int                     { return c.Size() }
+func emptyCollection(c con.Container) bool                   { return c.Empty() }
+func collectionValues(c con.Container) []Evaluable           { return valueSlice(c.Values()) }
+func collectionInterfaces(c con.Container) []interface{}     { return c.Values() }
+func clearCollection(c Collected, n con.Container) Collected { n.Clear(); return c }
+
+// valueKey returns a native key, identifying the value by its type and its
+// string representation. Evaluables are closures and can therefore neither be
+// compared, nor hashed by the gods containers. Plain values returned by the
+// native conversion are treated as integers.
+func valueKey(v Evaluable) string {
+	var t = v.Type()
+	if _, ok := v.(val); ok {
+		t = INTEGER
+	}
+	return t.String() + ":" + v.String()
+}
 func serializeCollection(c Collected, delims ...[]byte) []byte {
 	// serialize collection expects between zero and three byte slices to keep
 	// elements of the serialization seperated from one another and to seperate
@@ -142,20 +156,54 @@ func serializeCollection(c Collected, delims ...[]byte) []byte {
 }
 
 //// FUNCTIONS COMMON TO ALL LISTS
-func listToString(l Listed) string                                 { return string(serializeCollection(l, []byte("\n"))) }
-func serializeList(l Listed) []byte                                { return serializeCollection(l, []byte("\n")) }
-func getFromList(l Listed, i int) (Evaluable, bool)                { v, ok := l.(cl.List).Get(i); return Value(v), ok }
-func removeFromList(l Listed, i int) Listed                        { l.(cl.List).Remove(i); return l }
-func addToList(l Listed, v ...Evaluable) Listed                    { l.(cl.List).Add(interfaceSlice(v)...); return l }
-func addSliceOfInterfacesToList(l Listed, v ...interface{}) Listed { l.(cl.List).Add(v...); return l }
-func listContains(l Listed, v ...Evaluable) bool                   { return l.(cl.List).Contains(interfaceSlice(v)...) }
-func sortList(l Listed, c Compareable) Listed                      { l.(cl.List).Sort(c.InterfaceComparator()); return l }
-func swapList(l Listed, idx int, idy int) Listed                   { l.(cl.List).Swap(idx, idy); return l }
-func insertList(l Listed, i int, v ...Evaluable) Listed {
-	l.(cl.List).Insert(i, interfaceSlice(v)...)
+func listToString(l Listed) string                   { return string(serializeCollection(l, []byte("\n"))) }
+func serializeList(l Listed) []byte                  { return serializeCollection(l, []byte("\n")) }
+func getFromList(c cl.List, i int) (Evaluable, bool) { v, ok := c.Get(i); return Value(v), ok }
+func removeFromList(l Listed, c cl.List, i int) Listed {
+	c.Remove(i)
+	return l
+}
+func addToList(l Listed, c cl.List, v ...Evaluable) Listed {
+	c.Add(interfaceSlice(v)...)
+	return l
+}
+func addSliceOfInterfacesToList(l Listed, c cl.List, v ...interface{}) Listed {
+	c.Add(v...)
+	return l
+}
+func sortList(l Listed, c cl.List, cmp Compareable) Listed {
+	c.Sort(cmp.InterfaceComparator())
+	return l
+}
+func swapList(l Listed, c cl.List, idx int, idy int) Listed {
+	c.Swap(idx, idy)
+	return l
+}
+func insertList(l Listed, c cl.List, i int, v ...Evaluable) Listed {
+	c.Insert(i, interfaceSlice(v)...)
 	return l
 }
 
+// list elements are compared by value, since the contained closures can't be
+// compared by the list implementation itself
+func listContains(c cl.List, v ...Evaluable) bool {
+	var buckets = hashBuckets(valueSlice(c.Values()))
+	for _, v := range v {
+		if !containsEqual(buckets[Hash(v)], v) {
+			return false
+		}
+	}
+	return true
+}
+func containsEqual(s []Evaluable, v Evaluable) bool {
+	for _, e := range s {
+		if Equal(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
 // LIST GENERATORS
 func newArrayList() (r ArrayList) {
 	l := al.New()
@@ -173,14 +221,77 @@ func newSLLList() (r SLList) {
 	return r
 }
 
+// copyCollection returns a copy of collections backed by a mutable
+// container, so that altering the copy leaves the original untouched. The
+// containers enumerable selection keeps the comparator of ordered ones.
+// Persistent collections get returned as they are.
+func copyCollection(c Collected) Collected {
+	var all = func(int, interface{}) bool { return true }
+	var both = func(interface{}, interface{}) bool { return true }
+	switch x := c.(type) {
+	case ArrayList:
+		l := x().Select(all)
+		return ArrayList(func() *al.List { return l })
+	case DLList:
+		l := x().Select(all)
+		return DLList(func() *dl.List { return l })
+	case SLList:
+		l := x().Select(all)
+		return SLList(func() *sl.List { return l })
+	case ArrayStack: // values are returned top first
+		var r, v = newArraystack(), x().Values()
+		for i := len(v) - 1; i >= 0; i-- {
+			r().Push(v[i])
+		}
+		return r
+	case LinkedStack:
+		var r, v = newLinkedStack(), x().Values()
+		for i := len(v) - 1; i >= 0; i-- {
+			r().Push(v[i])
+		}
+		return r
+	case HashMap:
+		var r = newHashMap()
+		copyEntries(r(), x())
+		return r
+	case HashBidiMap:
+		var r = newHashBidiMap()
+		copyEntries(r(), x())
+		return r
+	case HashSet:
+		var r = newHashSet()
+		copyEntries(r(), x())
+		return r
+	case TreeMap:
+		m := x().Select(both)
+		return TreeMap(func() *tm.Map { return m })
+	case TreeBidiMap:
+		m := x().Select(both)
+		return TreeBidiMap(func() *tbm.Map { return m })
+	case TreeSet:
+		m := x().Select(all)
+		return TreeSet(func() *ts.Set { return m })
+	}
+	return c
+}
+
+// puts all entries of the source container to the target, as they are.
+// Hash buckets get shared, since they are replaced, never altered.
+func copyEntries(to, from cm.Map) {
+	for _, k := range from.Keys() {
+		e, _ := from.Get(k)
+		to.Put(k, e)
+	}
+}
+
 //// FUNCTIONS COMMON TO All STACKS
-func pushToStack(s Stacked, v Evaluable) Stacked { s.Push(v); return s }
-func popFromStack(s Stacked) (Evaluable, bool, Stacked) {
-	v, ok := s.(csa.Stack).Pop()
+func pushToStack(s Stacked, c csa.Stack, v Evaluable) Stacked { c.Push(v); return s }
+func popFromStack(s Stacked, c csa.Stack) (Evaluable, bool, Stacked) {
+	v, ok := c.Pop()
 	return Value(v), ok, s
 }
-func peekOnStack(s Stacked) (Evaluable, bool) {
-	v, ok := s.(csa.Stack).Peek()
+func peekOnStack(c csa.Stack) (Evaluable, bool) {
+	v, ok := c.Peek()
 	return Value(v), ok
 }
 func newArraystack() (r ArrayStack) {
@@ -195,41 +306,229 @@ func newLinkedStack() (r LinkedStack) {
 }
 
 //// FUNCTIONS COMMON TO All MAPS
-func Add(m Mapped, k Evaluable, v Evaluable) Mapped      { return putToMap(m, k, v) }
-func putToMap(m Mapped, k Evaluable, v Evaluable) Mapped { m.(cm.Map).Put(m, v); return m }
-func getFromMap(m Mapped, v Evaluable) (Evaluable, bool) {
-	val, ok := m.(cm.Map).Get(v)
-	return Value(val), ok
+// maps store each value as pair, together with the key it got mapped on to.
+// That way keys keep their type, which would otherwise get lost. Tree maps
+// key their entries by the key itself, hash maps by the hash of the key.
+func putToMap(m Mapped, c cm.Map, k Evaluable, v Evaluable) Mapped {
+	if !hashed(c) {
+		c.Put(k, pairFromValues(k, v))
+		return m
+	}
+	if _, ok := c.(*hbm.Map); ok { // values of bidirectional maps are unique
+		if x, ok := keyOfValue(c, v); ok && !Equal(x, k) {
+			removeFromMap(m, c, x)
+		}
+		removeFromMap(m, c, k)
+		putToBucket(c, valueHash(Hash(v)), pairFromValues(k, v))
+	}
+	putToBucket(c, Hash(k), pairFromValues(k, v))
+	return m
 }
-func removeFromMap(m Mapped, v Evaluable) Mapped { m.(cm.Map).Remove(v); return m }
-func keysOfMap(m Mapped) []Evaluable             { return valueSlice(m.(cm.Map).Keys()) }
-func valuesFromMap(m Mapped) []Evaluable         { return valueSlice(m.(con.Container).Values()) }
+func getFromMap(c cm.Map, k Evaluable) (Evaluable, bool) {
+	if !hashed(c) {
+		if e, ok := c.Get(k); ok {
+			return e.(Pair).Value(), true
+		}
+		return nil, false
+	}
+	if p, ok := bucketOf(c, Hash(k)).find(k); ok {
+		return p.Value(), true
+	}
+	return nil, false
+}
+func removeFromMap(m Mapped, c cm.Map, k Evaluable) Mapped {
+	if !hashed(c) {
+		c.Remove(k)
+		return m
+	}
+	var p, ok = bucketOf(c, Hash(k)).find(k)
+	if !ok {
+		return m
+	}
+	if _, ok := c.(*hbm.Map); ok {
+		removeFromBucket(c, valueHash(Hash(p.Value())), func(x Pair) bool { return Equal(x.Key(), k) })
+	}
+	removeFromBucket(c, Hash(k), func(x Pair) bool { return Equal(x.Key(), k) })
+	return m
+}
+
+// number of entries. Hash maps count the pairs of all their buckets.
+func mapSize(c cm.Map) int {
+	if !hashed(c) {
+		return c.Size()
+	}
+	var n = 0
+	for _, k := range c.Keys() {
+		if _, ok := k.(uint64); ok {
+			e, _ := c.Get(k)
+			n = n + len(e.(*bucket).pairs)
+		}
+	}
+	return n
+}
+
+// pairs are returned in the order of their keys, so that keys and values are
+// returned in corresponding order. Tree maps keep the order of their
+// comparator, hash maps are ordered canonically.
+func pairsOfMap(c cm.Map) []Pair {
+	var r = []Pair{}
+	if !hashed(c) {
+		for _, e := range c.Values() {
+			r = append(r, e.(Pair))
+		}
+		return r
+	}
+	var v = []Evaluable{}
+	for _, k := range c.Keys() {
+		if _, ok := k.(uint64); ok {
+			e, _ := c.Get(k)
+			for _, p := range e.(*bucket).pairs {
+				v = append(v, p)
+			}
+		}
+	}
+	for _, p := range sortValues(v, byKey) {
+		r = append(r, p.(Pair))
+	}
+	return r
+}
+func byKey(a, b Evaluable) int { return Compare(a.(Pair).Key(), b.(Pair).Key()) }
 
+// bidirectional tree maps order their entries by the paired value
+func entryComparator(a, b interface{}) int {
+	return Compare(a.(Pair).Value(), b.(Pair).Value())
+}
+func keysOfMap(c cm.Map) []Evaluable {
+	var r = []Evaluable{}
+	for _, p := range pairsOfMap(c) {
+		r = append(r, p.Key())
+	}
+	return r
+}
+func valuesFromMap(c cm.Map) []Evaluable {
+	var r = []Evaluable{}
+	for _, p := range pairsOfMap(c) {
+		r = append(r, p.Value())
+	}
+	return r
+}
+func interfacesFromMap(c cm.Map) []interface{} { return interfaceSlice(valuesFromMap(c)) }
+
+// maps from pairs serialize one pair per line, key and value delimited by a
+// colon
 func serializeMap(m Mapped) []byte {
 	var retval []byte
-	var keys = valueSlice(m.Keys())
-	var values = valueSlice(m.Values())
-	for i := len(values); i > 0; i-- {
-		i := i
-		retval = append(keys[i].Serialize(),
-			append([]byte(": "),
-				append(values[i].Serialize(),
-					[]byte("\n")...,
+	var keys = m.Keys()
+	var values = m.Values()
+	for i := range keys {
+		retval = append(retval,
+			append(keys[i].Serialize(),
+				append([]byte(": "),
+					append(values[i].Serialize(),
+						[]byte("\n")...,
+					)...,
 				)...,
 			)...,
 		)
-
 	}
-
 	return retval
 }
-func interfacesFromMap(m Mapped) []interface{} { return m.(cm.Map).Values() }
-func mapToString(m Mapped) string              { return string(m.Serialize()) }
+func mapToString(m Mapped) string { return string(m.Serialize()) }
 
 //// FUNCTIONS COMMON TO All BIDIRECTIONAL MAPS
-func getKeyFromMap(m Mapped, v Evaluable) (Evaluable, bool) {
-	r, ok := m.(cm.BidiMap).GetKey(v)
-	return Value(r), ok
+func getKeyFromMap(c cm.BidiMap, v Evaluable) (Evaluable, bool) { return keyOfValue(c, v) }
+
+// bidirectional hash maps look the key up in the bucket of the values hash,
+// tree maps by their value comparator.
+func keyOfValue(c cm.Map, v Evaluable) (Evaluable, bool) {
+	switch x := c.(type) {
+	case *hbm.Map:
+		if b := bucketOf(c, valueHash(Hash(v))); b != nil {
+			for _, p := range b.pairs {
+				if Equal(p.Value(), v) {
+					return p.Key(), true
+				}
+			}
+		}
+	case *tbm.Map:
+		if k, ok := x.GetKey(pairFromValues(nil, v)); ok {
+			return k.(Evaluable), true
+		}
+	}
+	return nil, false
+}
+
+//// HASH BUCKETS
+// hash maps and sets map the hash of each key on to a bucket of all pairs
+// with keys of that hash. Keys that are not equal, but share a hash, are told
+// apart by Equal, like the entries of the hash array mapped trie. Buckets are
+// replaced, instead of altered, so that copies of a container can share them.
+// Bidirectional hash maps keep a second bucket per hash of the paired values,
+// keyed by valueHash, to look up keys by their value.
+type (
+	bucket    struct{ pairs []Pair }
+	valueHash uint64
+)
+
+func hashed(c cm.Map) bool {
+	switch c.(type) {
+	case *hm.Map, *hbm.Map:
+		return true
+	}
+	return false
+}
+
+// bucket stored at the key, nil if there is none
+func bucketOf(c cm.Map, k interface{}) *bucket {
+	if e, ok := c.Get(k); ok {
+		return e.(*bucket)
+	}
+	return nil
+}
+
+// pair of the equal key
+func (b *bucket) find(k Evaluable) (Pair, bool) {
+	if b != nil {
+		for _, p := range b.pairs {
+			if Equal(p.Key(), k) {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// puts the pair into a copy of the bucket at k, replacing the pair of an equal
+// key
+func putToBucket(c cm.Map, k interface{}, p Pair) {
+	var r = &bucket{[]Pair{p}}
+	if b := bucketOf(c, k); b != nil {
+		for _, x := range b.pairs {
+			if !Equal(x.Key(), p.Key()) {
+				r.pairs = append(r.pairs, x)
+			}
+		}
+	}
+	c.Put(k, r)
+}
+
+// copy of the bucket at k without the matching pairs, removed if empty
+func removeFromBucket(c cm.Map, k interface{}, match func(Pair) bool) {
+	var b = bucketOf(c, k)
+	if b == nil {
+		return
+	}
+	var r = &bucket{}
+	for _, x := range b.pairs {
+		if !match(x) {
+			r.pairs = append(r.pairs, x)
+		}
+	}
+	if len(r.pairs) == 0 {
+		c.Remove(k)
+		return
+	}
+	c.Put(k, r)
 }
 func newHashMap() (r HashMap) {
 	m := hm.New()
@@ -241,61 +540,102 @@ func newHashBidiMap() (r HashBidiMap) {
 	r = func() *hbm.Map { return m }
 	return r
 }
-func newTreeMapNumeric() (r TreeMap) {
-	m := tm.NewWithIntComparator()
-	r = func() *tm.Map { return m }
-	return r
-}
-func newTreeMapSymbolic() (r TreeMap) {
-	m := tm.NewWithStringComparator()
+
+// tree maps and sets, heaps and red black trees order their elements
+// canonically, unless passed another comparator
+func newTreeMap(c ...Compareable) (r TreeMap) {
+	m := tm.NewWith(comparator(c).InterfaceComparator())
 	r = func() *tm.Map { return m }
 	return r
 }
-func newTreeBidiMapNumeric() (r TreeBidiMap) {
-	m := tbm.NewWithIntComparators()
+func newTreeBidiMap(c ...Compareable) (r TreeBidiMap) {
+	m := tbm.NewWith(comparator(c).InterfaceComparator(), entryComparator)
 	r = func() *tbm.Map { return m }
 	return r
 }
-func newTreeBidiMapSymbolic() (r TreeBidiMap) {
-	m := tbm.NewWithStringComparators()
-	r = func() *tbm.Map { return m }
-	return r
+func comparator(c []Compareable) Compareable {
+	if len(c) > 0 && c[0] != nil {
+		return c[0]
+	}
+	return Ascending
 }
 
 //// FUNCTIONS COMMON TO All SETS OF UNIQUE ELEMENTS
-func removeFromSet(u DeDublicated, i int) DeDublicated { u.(cs.Set).Remove(i); return u }
-func addToSet(u DeDublicated, v ...Evaluable) DeDublicated {
-	u.(cs.Set).Add(interfaceSlice(v)...)
+func removeFromSet(u DeDublicated, c cs.Set, v ...Evaluable) DeDublicated {
+	c.Remove(interfaceSlice(v)...)
+	return u
+}
+func addToSet(u DeDublicated, c cs.Set, v ...Evaluable) DeDublicated {
+	c.Add(interfaceSlice(v)...)
 	return u
 }
-func setContains(u DeDublicated, v ...Evaluable) bool {
-	return u.(cs.Set).Contains(interfaceSlice(v)...)
+func setContains(c cs.Set, v ...Evaluable) bool {
+	return c.Contains(interfaceSlice(v)...)
 }
 func interfacesFromSet(u DeDublicated) []interface{} { return interfaceSlice(u.Values()) }
 func newHashSet(v ...Evaluable) (r HashSet) {
-	m := hs.New()
-	r = func() *hs.Set { return m }
+	m := hm.New()
+	r = func() *hm.Map { return m }
+	r.Add(v...)
 	return r
 }
-func newTreeSetNumeric() (r TreeSet) {
-	m := ts.NewWithIntComparator()
-	r = func() *ts.Set { return m }
+
+// hash sets map the hash of each value on to a bucket of the values with
+// that hash, each paired with itself.
+func addToHashSet(u DeDublicated, c *hm.Map, v ...Evaluable) DeDublicated {
+	for _, v := range v {
+		putToBucket(c, Hash(v), pairFromValues(v, v))
+	}
+	return u
+}
+func removeFromHashSet(u DeDublicated, c *hm.Map, v ...Evaluable) DeDublicated {
+	for _, v := range v {
+		removeFromBucket(c, Hash(v), func(x Pair) bool { return Equal(x.Key(), v) })
+	}
+	return u
+}
+func hashSetContains(c *hm.Map, v ...Evaluable) bool {
+	for _, v := range v {
+		if _, ok := bucketOf(c, Hash(v)).find(v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// values in canonical order
+func hashSetValues(c *hm.Map) []Evaluable {
+	var r = []Evaluable{}
+	for _, e := range c.Values() {
+		for _, p := range e.(*bucket).pairs {
+			r = append(r, p.Key())
+		}
+	}
+	return sortValues(r, Ascending)
+}
+
+// one value per line
+func serializeSet(u DeDublicated) []byte {
+	var r = []byte{}
+	for _, v := range u.Values() {
+		r = append(append(r, v.Serialize()...), '\n')
+	}
 	return r
 }
-func newTreeSetSymbolic() (r TreeSet) {
-	m := ts.NewWithStringComparator()
+func newTreeSet(c ...Compareable) (r TreeSet) {
+	m := ts.NewWith(comparator(c).InterfaceComparator())
 	r = func() *ts.Set { return m }
 	return r
 }
 
 /////////////// TREE /////////////////
-func newHeap() (r Heap) {
-	m := ht.NewWithIntComparator()
+func newHeap(c ...Compareable) (r Heap) {
+	m := ht.NewWith(comparator(c).InterfaceComparator())
 	r = func() *ht.Heap { return m }
 	return r
 }
-func newRedBlack() (r RedBlack) {
-	m := rbt.NewWithStringComparator()
+func newRedBlack(c ...Compareable) (r RedBlack) {
+	m := rbt.NewWith(comparator(c).InterfaceComparator())
 	r = func() *rbt.Tree { return m }
 	return r
 }
@@ -477,22 +817,14 @@ func newOrderedList(v ...Evaluable) ArrayList {
 	return func() *al.List { return l }
 }
 
+// NewArrayList allocates an array list containing the passed values
+func NewArrayList(v ...Evaluable) ArrayList { return newOrderedList(v...) }
+
 // MAP FROM PAIRS OF VALUES
 func unorderedBidiMapFromPairs(v ...Pair) HashBidiMap {
-	var r = hbm.New()
+	var r = newHashBidiMap()
 	for _, v := range v {
-		k := v.Key()
-		v := v.Value()
-		switch {
-		case k.Type()&SYMBOLIC != 0:
-			(*r).Put(k.Serialize(), v)
-		case k.Type()&NATURAL != 0:
-			(*r).Put(k.(val).Int(), v)
-		case k.Type()&REAL != 0:
-			// both parts of ratio are stored in the value field,
-			// numerator is taken as the index
-			(*r).Put(val(k.(ratio).Num()).Int(), v)
-		}
+		r.Put(v.Key(), v.Value())
 	}
-	return func() *hbm.Map { return r }
+	return r
 }
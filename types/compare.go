@@ -0,0 +1,221 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+//////////////////////////////////////////////////////////////////////////
+//// CANONICAL ORDER
+///
+// all values are totally ordered. Values of different types are ordered by
+// the rank of their type, numbers before symbols, symbols before pairs and
+// pairs before collections. Values of the same type are ordered numerically,
+// if numeric, lexicographically by their content, if symbolic and element
+// wise, if collected. Sets and maps are compared in canonical order of their
+// elements, or keys respectively. The order agrees with Equal: values compare
+// as zero, if and only if they are equal.
+
+// ready made comparators
+var (
+	// canonical order
+	Ascending Compareable = Compare
+	// reversed canonical order
+	Descending Compareable = func(a, b Evaluable) int { return Compare(b, a) }
+	// symbolic values compare numbers embedded in them by their numeric
+	// value (file2 before file10), all other values in canonical order.
+	Natural Compareable = func(a, b Evaluable) int {
+		if a != nil && b != nil && a.Type()&SYMBOLIC != 0 && a.Type() == b.Type() {
+			return naturalCompare(string(a.Serialize()), string(b.Serialize()))
+		}
+		return Compare(a, b)
+	}
+)
+
+// ranks of all value types, unknown types rank last
+var typeRanks = map[ValueType]int{
+	EMPTY:    0,
+	BOOL:     1,
+	UINT:     2,
+	INTEGER:  3,
+	DECIMAL:  4,
+	RATIONAL: 5,
+	FLOAT:    6,
+	BYTES:    7,
+	TEXT:     8,
+	PAIR:     9,
+	FLAG:     10,
+	LIST:     11,
+	STACK:    12,
+	TABLE:    13,
+	MATRIX:   14,
+	SET:      15,
+	MAP:      16,
+}
+
+func typeRank(t ValueType) int {
+	if r, ok := typeRanks[t]; ok {
+		return r
+	}
+	return len(typeRanks) + int(t)
+}
+
+// Compare returns -1, if a orders before b, +1 if it orders after b and zero
+// if both are equal. Nil orders before all other values.
+func Compare(a, b Evaluable) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		}
+		return 1
+	}
+	var ta, tb = scalarType(a), scalarType(b)
+	if ta != tb {
+		return compareInts(typeRank(ta), typeRank(tb))
+	}
+	if ta == FLAG {
+		return flagInt(a).Cmp(flagInt(b))
+	}
+	switch x := a.(type) {
+	case Float:
+		if y, ok := b.(Float); ok {
+			return x().Cmp(y())
+		}
+	case Pair:
+		if y, ok := b.(Pair); ok {
+			if c := Compare(x.Key(), y.Key()); c != 0 {
+				return c
+			}
+			return Compare(x.Value(), y.Value())
+		}
+	case Mapped:
+		if y, ok := b.(Mapped); ok {
+			return compareSequence(sortValues(mapEntries(x), Compare),
+				sortValues(mapEntries(y), Compare))
+		}
+	case DeDublicated:
+		if y, ok := b.(DeDublicated); ok {
+			return compareSequence(sortValues(x.Values(), Compare),
+				sortValues(y.Values(), Compare))
+		}
+	case LabeledTable: // column keys first, then the rows in order
+		if y, ok := b.(LabeledTable); ok {
+			return compareSequence(labeledEntries(x), labeledEntries(y))
+		}
+	case Tabular: // shape first, then the elements in row major order
+		if y, ok := b.(Tabular); ok {
+			if c := compareSequence(shapeOf(x), shapeOf(y)); c != 0 {
+				return c
+			}
+			return compareSequence(x.Values(), y.Values())
+		}
+	case Collected:
+		if y, ok := b.(Collected); ok {
+			return compareSequence(x.Values(), y.Values())
+		}
+	}
+	if ta&NUMERIC != 0 {
+		x, okx := bigRatOf(a)
+		y, oky := bigRatOf(b)
+		if okx && oky {
+			return x.Cmp(y)
+		}
+	}
+	return bytes.Compare(canonical(a), canonical(b))
+}
+
+// bits of flags, token-, node- and value type sets
+func flagInt(v Evaluable) *big.Int {
+	switch x := v.(type) {
+	case BitFlag:
+		return x()
+	case ValueType:
+		return new(big.Int).SetUint64(uint64(x))
+	case TokenType:
+		return new(big.Int).SetUint64(uint64(x))
+	case NodeType:
+		return new(big.Int).SetUint64(uint64(x))
+	}
+	if i, ok := bigIntOf(v); ok {
+		return i
+	}
+	return new(big.Int)
+}
+
+// element wise, a sequence that is the beginning of another one, orders first
+func compareSequence(a, b []Evaluable) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// sorted copy of the values
+func sortValues(v []Evaluable, c Compareable) []Evaluable {
+	var r = sortable{append([]Evaluable{}, v...), c}
+	sort.Sort(r)
+	return r.v
+}
+
+type sortable struct {
+	v []Evaluable
+	c Compareable
+}
+
+func (s sortable) Len() int           { return len(s.v) }
+func (s sortable) Swap(i, j int)      { s.v[i], s.v[j] = s.v[j], s.v[i] }
+func (s sortable) Less(i, j int) bool { return s.c(s.v[i], s.v[j]) < 0 }
+
+// compares runs of digits by their numeric value and everything else
+// lexicographically. Equal numbers with more leading zeros order last.
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		var x, y string
+		x, a = leadingRun(a)
+		y, b = leadingRun(b)
+		if isDigit(x[0]) && isDigit(y[0]) {
+			var tx, ty = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+			if c := compareInts(len(tx), len(ty)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(tx, ty); c != 0 {
+				return c
+			}
+			if c := compareInts(len(x), len(y)); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := strings.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+// splits off the leading run of either digits, or non digits
+func leadingRun(s string) (string, string) {
+	var i = 1
+	for i < len(s) && isDigit(s[i]) == isDigit(s[0]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+func isDigit(b byte) bool { return '0' <= b && b <= '9' }
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+// values are compared by type rank first and by value second
+var compareTests = []struct {
+	opStr    string
+	c        Compareable
+	operands func() (a, b Evaluable)
+	exp      int
+}{
+	{"Integer", Ascending, func() (a, b Evaluable) { return NewInteger(-3), NewInteger(2) }, -1},
+	{"equal", Ascending, func() (a, b Evaluable) { return Value(2), NewInteger(2) }, 0},
+	{"Ratio", Ascending, func() (a, b Evaluable) { return newRatio(2, 3), newRatio(1, 2) }, 1},
+	{"Decimal scale", Ascending, func() (a, b Evaluable) { return parseDecimal("1.50"), parseDecimal("1.5") }, 0},
+	{"Float", Ascending, func() (a, b Evaluable) { return NewFloat(0.25), NewFloat(0.5) }, -1},
+	{"Float Inf", Ascending, func() (a, b Evaluable) {
+		var inf, _ = ParseFloat("+Inf", 64, 0)
+		return inf, NewFloat(1e300)
+	}, 1},
+	{"numbers before symbols", Ascending, func() (a, b Evaluable) { return NewText("0"), NewInteger(100) }, 1},
+	{"Natural mixed", Natural, func() (a, b Evaluable) { return NewText("x2"), NewInteger(10) }, 1},
+	{"Pair value", Ascending, func() (a, b Evaluable) { return Value("a", 2), Value("a", 1) }, 1},
+	{"Pair key", Ascending, func() (a, b Evaluable) { return Value("a", 2), Value("b", 1) }, -1},
+	{"List", Ascending, func() (a, b Evaluable) {
+		return NewArrayList(NewInteger(1), NewInteger(2)), NewArrayList(NewInteger(1), NewInteger(3))
+	}, -1},
+	{"List prefix", Ascending, func() (a, b Evaluable) {
+		return NewArrayList(NewInteger(1)), NewArrayList(NewInteger(1), NewInteger(0))
+	}, -1},
+	{"Set order", Ascending, func() (a, b Evaluable) {
+		return newHashSet(NewInteger(2), NewInteger(1)), newHashSet(NewInteger(1), NewInteger(2))
+	}, 0},
+	{"flags", Ascending, func() (a, b Evaluable) { return NewFlag(3), TOKEN_DOCUMENT_HEADER.Flag() }, 1},
+	{"nil", Ascending, func() (a, b Evaluable) { return nil, NewInteger(0) }, -1},
+	{"nil both", Ascending, func() (a, b Evaluable) { return nil, nil }, 0},
+	{"agrees with Equal", Ascending, func() (a, b Evaluable) {
+		return NewArrayList(NewText("x"), newRatio(1, 2)), NewArrayList(NewText("x"), newRatio(2, 4))
+	}, 0},
+}
+
+func TestCompare(t *testing.T) {
+	for n, test := range compareTests {
+		var a, b = test.operands()
+		var got = test.c(a, b)
+		if got != test.exp || (got == 0) != Equal(a, b) {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %d equal: %v expected: %d",
+				n, test.opStr, got, Equal(a, b), test.exp))
+		}
+	}
+}
+
+// values are passed as natives and sorted by the comparator
+var sortTests = []struct {
+	opStr string
+	c     Compareable
+	v     []interface{}
+	exp   []interface{}
+}{
+	{"type rank", Ascending, []interface{}{"a", 0.5, 7, true, uint(9)}, []interface{}{true, uint(9), 7, 0.5, "a"}},
+	{"Text", Ascending, []interface{}{"b", "ab", "a"}, []interface{}{"a", "ab", "b"}},
+	{"Descending", Descending, []interface{}{1, 3, 2}, []interface{}{3, 2, 1}},
+	{"Natural", Natural, []interface{}{"file10", "file2", "file1"}, []interface{}{"file1", "file2", "file10"}},
+	{"Natural lexicographic", Ascending, []interface{}{"file10", "file2", "file1"}, []interface{}{"file1", "file10", "file2"}},
+	{"Natural zeros", Natural, []interface{}{"a01", "a1", "a001b"}, []interface{}{"a1", "a01", "a001b"}},
+}
+
+func TestSort(t *testing.T) {
+	for n, test := range sortTests {
+		var l = nativeValue(test.v).(ArrayList)
+		testValue(t, n, test.opStr, NewArrayList(sortValues(l.Values(), test.c)...), LIST, nativeValue(test.exp))
+	}
+}
+
+// ordered collections keep their elements sorted by the comparator. Results
+// of more than one value are returned as list.
+var orderedTests = []struct {
+	opStr string
+	typ   ValueType
+	exp   interface{}
+	op    func() Evaluable
+}{
+	{"List Sort", LIST, []interface{}{"a", "b9", "b10"}, func() Evaluable {
+		return NewArrayList(NewText("b10"), NewText("b9"), NewText("a")).Sort(Natural).(ArrayList)
+	}},
+	{"TreeMap", LIST, []interface{}{[]interface{}{1, "a", "b"}, 1, true}, func() Evaluable {
+		var m = newTreeMap().Put(NewText("b"), NewInteger(2)).Put(NewInteger(1), NewText("one")).Put(NewText("a"), NewInteger(1))
+		v, ok := m.(TreeMap).Get(NewText("a"))
+		return NewArrayList(NewArrayList(m.Keys()...), v, Value(ok))
+	}},
+	{"TreeMap descending", LIST, []interface{}{2, 1}, func() Evaluable {
+		var m = newTreeMap(Descending).Put(NewInteger(1), NewText("x")).Put(NewInteger(2), NewText("y"))
+		return NewArrayList(m.Keys()...)
+	}},
+	{"TreeMap Remove", INTEGER, 0, func() Evaluable {
+		return NewInteger(int64(newTreeMap().Put(NewText("a"), NewInteger(1)).Remove(NewText("a")).Size()))
+	}},
+	{"TreeBidiMap", LIST, []interface{}{1, "b", true}, func() Evaluable {
+		var m = newTreeBidiMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(1))
+		k, ok := getKeyFromMap(m.(TreeBidiMap)(), NewInteger(1))
+		return NewArrayList(NewInteger(int64(m.Size())), k, Value(ok))
+	}},
+	{"TreeSet", LIST, []interface{}{3, []interface{}{3, "a", "b"}, true}, func() Evaluable {
+		var s = newTreeSet().Add(NewText("b"), NewInteger(3), NewText("a"), NewInteger(3))
+		return NewArrayList(NewInteger(int64(s.Size())), NewArrayList(s.Values()...), Value(s.Contains(NewText("a"))))
+	}},
+	{"TreeSet Remove", LIST, []interface{}{2}, func() Evaluable {
+		return NewArrayList(newTreeSet().Add(NewInteger(1), NewInteger(2)).Remove(NewInteger(1)).Values()...)
+	}},
+	{"Heap", INTEGER, 1, func() Evaluable {
+		var h = newHeap()
+		h().Push(NewInteger(3), NewInteger(1), NewInteger(2))
+		v, _ := h().Pop()
+		return v.(Evaluable)
+	}},
+	{"RedBlack", LIST, []interface{}{"n9", "n10"}, func() Evaluable {
+		var t = newRedBlack(Natural)
+		t().Put(NewText("n10"), 1)
+		t().Put(NewText("n9"), 2)
+		var r = []Evaluable{}
+		for _, k := range t().Keys() {
+			r = append(r, k.(Evaluable))
+		}
+		return NewArrayList(r...)
+	}},
+}
+
+func TestOrdered(t *testing.T) {
+	for n, test := range orderedTests {
+		testValue(t, n, test.opStr, test.op(), test.typ, nativeValue(test.exp))
+	}
+}
@@ -41,10 +41,50 @@ const (
 )
 
 //go:generate stringer -type ValueType
-type ValueType uint16
+type ValueType uint32
 
 func (v ValueType) Uint() uint { return uint(v) }
 
+// a set of value types as flag
+func (v ValueType) Flag() BitFlag {
+	return wrap(intPool.Get().(*big.Int).SetUint64(uint64(v))).(val).bitFlag()
+}
+
+// a set of value types is evaluable as a flag
+func (v ValueType) Type() ValueType { return FLAG }
+func (v ValueType) Eval() Evaluable { return v }
+
+// Match determines if all passed value types are contained in the set
+func (v ValueType) Match(x ValueType) bool { return v&x == x }
+
+// Kinds splits the set into its single value types in ascending order. All
+// bits of the mask are considered, so that types added later are included.
+func (v ValueType) Kinds() []ValueType {
+	var r = []ValueType{}
+	for k := BOOL; k&MASK != 0; k <<= 1 {
+		if v&k != 0 {
+			r = append(r, k)
+		}
+	}
+	return r
+}
+
+// single value types serialize to their name, sets to the names of all
+// contained types, delimited by a pipe symbol.
+func (v ValueType) Serialize() []byte {
+	var r = []byte{}
+	for n, k := range v.Kinds() {
+		if n > 0 {
+			r = append(r, '|')
+		}
+		r = append(r, []byte(k.String())...)
+	}
+	if len(r) == 0 {
+		r = []byte(EMPTY.String())
+	}
+	return r
+}
+
 // DYNAMIC TYPES
 // All dynamic types are defined as function types. Methods are defined on
 // those functional types, To implement the variable and all higher level
@@ -71,13 +111,19 @@ const ( // NUMERIC TYPES
 	SET    // *treeset.Set	    ← 8192  << 12
 	MAP    // *maps.Map	    ← 16384  << 12
 	//	    ← 32768  << 12
+	// DECIMAL FIXED POINT
+	DECIMAL // *big.Int, scale ← 65536  << 16
+	// DOCUMENT TREE
+	NODE // *tokens.Node	    ← 131072 << 17
+	// FAILED EVALUATION
+	ERROR // tokens.CellError  ← 262144 << 18
 
 	//////////// BIT FLAG SETS /////////////
 	/////////////////
 	// SEMANTIC SETS:
 	BITWISE = UINT | FLAG | BOOL             // bitwise operable
 	NUMERIC = BOOL | FLAG | UINT | INTEGER | // arithmetic operable…
-		RATIONAL | FLOAT // …possibly not enumerable
+		DECIMAL | RATIONAL | FLOAT // …possibly not enumerable
 	SYMBOLIC = BYTES | TEXT // syntacticly operable
 	////////////////////////////////
 	//// NUMERIC (SEMANTIC SUBSETS):
@@ -92,7 +138,7 @@ const ( // NUMERIC TYPES
 	// counted), while carrying individual numerators (number of
 	// positive/negative occurences involving a particular item)
 	//
-	REAL = FLOAT | RATIONAL // both get stored as natural
+	REAL = FLOAT | RATIONAL | DECIMAL // all get stored as natural
 	// nunber pair, quotient might be irrational nevertheless.
 	NATURAL = BOOL | UINT | FLAG | INTEGER // sign relevant for arrithmetic
 	// use only
@@ -107,7 +153,7 @@ const ( // NUMERIC TYPES
 	// …handled like a list of bools)
 
 	// convienience mask with all bits set for bitwise operations
-	MASK = (1 << 16) - 1
+	MASK = (1 << 19) - 1
 )
 
 //go:generate stringer -type BoolType
@@ -124,7 +170,184 @@ const (
 	NODE_TYPE                       // returns NodeType Flag
 )
 
-//defined by runes, stored as uint32
+//go:generate stringer -type RoundingMode
+type RoundingMode uint8
+
+// rounding modes of decimals
+const (
+	HALF_EVEN RoundingMode = iota // round half to even (bankers rounding)
+	HALF_UP                       // round half away from zero
+	DOWN                          // truncate towards zero
+)
+
+//go:generate stringer -type StringMode
+type StringMode uint8
+
+// string ingestion modes, determining how native strings get interpreted
+const (
+	AS_TEXT    StringMode = 0 // strings allways become Text
+	AS_NUMERIC StringMode = 1 // numeric strings become Integer, all others Text
+)
+
+//go:generate stringer -type TokenType
+type TokenType uint32
+
+// TOKEN TYPES
+// one token type per callback of the blackfriday renderer interface. Token
+// types are bit flags, so that sets of token types can be expressed as
+// TokenType as well.
+const (
+	TOKEN_NONE            TokenType = 0
+	TOKEN_DOCUMENT_HEADER TokenType = 1 << iota
+	TOKEN_DOCUMENT_FOOTER
+	// BLOCK LEVEL
+	TOKEN_BLOCK_CODE
+	TOKEN_BLOCK_QUOTE
+	TOKEN_BLOCK_HTML
+	TOKEN_HEADER
+	TOKEN_HRULE
+	TOKEN_LIST
+	TOKEN_LIST_ITEM
+	TOKEN_PARAGRAPH
+	TOKEN_TABLE
+	TOKEN_TABLE_ROW
+	TOKEN_TABLE_HEADER_CELL
+	TOKEN_TABLE_CELL
+	TOKEN_FOOTNOTES
+	TOKEN_FOOTNOTE_ITEM
+	TOKEN_TITLE_BLOCK
+	// SPAN LEVEL
+	TOKEN_AUTO_LINK
+	TOKEN_CODE_SPAN
+	TOKEN_DOUBLE_EMPHASIS
+	TOKEN_EMPHASIS
+	TOKEN_IMAGE
+	TOKEN_LINE_BREAK
+	TOKEN_LINK
+	TOKEN_RAW_HTML_TAG
+	TOKEN_TRIPLE_EMPHASIS
+	TOKEN_STRIKE_THROUGH
+	TOKEN_FOOTNOTE_REF
+	// LOW LEVEL
+	TOKEN_ENTITY
+	TOKEN_NORMAL_TEXT
+
+	//////////// TOKEN TYPE SETS /////////////
+	TOKEN_DOCUMENT = TOKEN_DOCUMENT_HEADER | TOKEN_DOCUMENT_FOOTER
+	TOKEN_BLOCK    = TOKEN_BLOCK_CODE | TOKEN_BLOCK_QUOTE | TOKEN_BLOCK_HTML |
+		TOKEN_HEADER | TOKEN_HRULE | TOKEN_LIST | TOKEN_LIST_ITEM |
+		TOKEN_PARAGRAPH | TOKEN_TABLE | TOKEN_TABLE_ROW |
+		TOKEN_TABLE_HEADER_CELL | TOKEN_TABLE_CELL | TOKEN_FOOTNOTES |
+		TOKEN_FOOTNOTE_ITEM | TOKEN_TITLE_BLOCK
+	TOKEN_SPAN = TOKEN_AUTO_LINK | TOKEN_CODE_SPAN | TOKEN_DOUBLE_EMPHASIS |
+		TOKEN_EMPHASIS | TOKEN_IMAGE | TOKEN_LINE_BREAK | TOKEN_LINK |
+		TOKEN_RAW_HTML_TAG | TOKEN_TRIPLE_EMPHASIS | TOKEN_STRIKE_THROUGH |
+		TOKEN_FOOTNOTE_REF
+	TOKEN_LOW_LEVEL = TOKEN_ENTITY | TOKEN_NORMAL_TEXT
+	// callbacks that get passed a closure to render nested content
+	TOKEN_NESTED = TOKEN_HEADER | TOKEN_LIST | TOKEN_PARAGRAPH | TOKEN_FOOTNOTES
+
+	// convienience mask with all token type bits set
+	TOKEN_MASK = (TOKEN_NORMAL_TEXT << 1) - 2
+)
+
+// a set of token types is evaluable as a flag
+func (t TokenType) Type() ValueType { return FLAG }
+func (t TokenType) Eval() Evaluable { return t }
+func (t TokenType) Flag() BitFlag {
+	return wrap(intPool.Get().(*big.Int).SetUint64(uint64(t))).(val).bitFlag()
+}
+
+// Match determines if all passed token types are contained in the set
+func (t TokenType) Match(x TokenType) bool { return t&x == x }
+
+// Kinds splits the set into its single token types in ascending order
+func (t TokenType) Kinds() []TokenType {
+	var r = []TokenType{}
+	for k := TOKEN_DOCUMENT_HEADER; k <= TOKEN_NORMAL_TEXT; k <<= 1 {
+		if t&k != 0 {
+			r = append(r, k)
+		}
+	}
+	return r
+}
+
+// single token types serialize to their name, sets to the names of all
+// contained types, delimited by a pipe symbol.
+func (t TokenType) Serialize() []byte {
+	var r = []byte{}
+	for n, k := range t.Kinds() {
+		if n > 0 {
+			r = append(r, '|')
+		}
+		r = append(r, []byte(k.String())...)
+	}
+	if len(r) == 0 {
+		r = []byte(TOKEN_NONE.String())
+	}
+	return r
+}
+
+//go:generate stringer -type NodeType
+type NodeType uint8
+
+// NODE TYPES
+// flags that describe the role of a node within the document tree, as well as
+// the events emitted, when walking the tree.
+const (
+	NODE_NONE  NodeType = 0
+	NODE_OPEN  NodeType = 1 << iota // event: node entered, children follow
+	NODE_CLOSE                      // event: node left, all children visited
+	NODE_ROOT                       // document root
+	NODE_BLOCK                      // block level element
+	NODE_SPAN                       // span-, or low level element
+	NODE_LEAF                       // element without children
+
+	//////////// NODE TYPE SETS /////////////
+	NODE_EVENT = NODE_OPEN | NODE_CLOSE
+	NODE_MASK  = NODE_OPEN | NODE_CLOSE | NODE_ROOT |
+		NODE_BLOCK | NODE_SPAN | NODE_LEAF
+)
+
+// a set of node types is evaluable as a flag
+func (n NodeType) Type() ValueType { return FLAG }
+func (n NodeType) Eval() Evaluable { return n }
+func (n NodeType) Flag() BitFlag {
+	return wrap(intPool.Get().(*big.Int).SetUint64(uint64(n))).(val).bitFlag()
+}
+
+// Match determines if all passed node types are contained in the set
+func (n NodeType) Match(x NodeType) bool { return n&x == x }
+
+// Kinds splits the set into its single node types in ascending order
+func (n NodeType) Kinds() []NodeType {
+	var r = []NodeType{}
+	for k := NODE_OPEN; k <= NODE_LEAF; k <<= 1 {
+		if n&k != 0 {
+			r = append(r, k)
+		}
+	}
+	return r
+}
+
+// single node types serialize to their name, sets to the names of all
+// contained types, delimited by a pipe symbol.
+func (n NodeType) Serialize() []byte {
+	var r = []byte{}
+	for i, k := range n.Kinds() {
+		if i > 0 {
+			r = append(r, '|')
+		}
+		r = append(r, []byte(k.String())...)
+	}
+	if len(r) == 0 {
+		r = []byte(NODE_NONE.String())
+	}
+	return r
+}
+
+// defined by runes, stored as uint32
+//
 //go:generate stringer -type Reserved
 type Reserved uint32 // ← identical with rune type
 
@@ -0,0 +1,206 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+)
+
+/////////////////////////////////////////////////////////////////////////
+// DECIMAL
+// decimals are exact base ten fixed point numbers, stored as big Int
+// mantissa and a scale, which is the number of digits after the decimal
+// point. 1234.50 is stored as mantissa 123450 with a scale of 2. The scale is
+// never negative. Sums and differences get the larger scale of both
+// operands, products the sum of both scales. Quotients and rescaling to
+// fewer digits round according to the passed rounding mode.
+type Decimal dec
+
+func (d Decimal) Eval() Evaluable   { return d }
+func (d Decimal) Serialize() []byte { return []byte(d.String()) }
+func (d Decimal) Type() ValueType   { return DECIMAL }
+
+// locale neutral representation, with a point as separator and all digits
+// of the scale, like "1234.50"
+func (d Decimal) String() string {
+	var m, s = d()
+	var digits = new(big.Int).Abs(m).String()
+	var sign = ""
+	if m.Sign() < 0 {
+		sign = "-"
+	}
+	if s == 0 {
+		return sign + digits
+	}
+	if len(digits) <= s {
+		digits = strings.Repeat("0", s-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-s] + "." + digits[len(digits)-s:]
+}
+
+func (d Decimal) Mantissa() Integer {
+	var m, _ = d()
+	return wrap(intPool.Get().(*big.Int).Set(m)).(val).Integer()
+}
+func (d Decimal) Scale() int  { var _, s = d(); return s }
+func (d Decimal) Sign() int   { var m, _ = d(); return m.Sign() }
+func (d Decimal) IsInt() bool { return d.Ratio().IsInt() }
+
+// Rescale returns the decimal with the passed number of digits after the
+// point, rounding according to mode, if digits get dropped.
+func (d Decimal) Rescale(scale int, mode RoundingMode) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	var m, s = d()
+	if scale >= s {
+		return decimal(new(big.Int).Mul(m, pow10(scale-s)), scale)
+	}
+	return roundRat(new(big.Rat).SetFrac(m, pow10(s)), scale, mode)
+}
+
+func (d Decimal) Neg() Decimal {
+	var m, s = d()
+	return decimal(new(big.Int).Neg(m), s)
+}
+func (d Decimal) Abs() Decimal {
+	var m, s = d()
+	return decimal(new(big.Int).Abs(m), s)
+}
+
+// Cmp returns -1, 0, or +1, if d is less than, equal to, or greater than y
+func (d Decimal) Cmp(y Decimal) int {
+	var a, b, _ = alignDecimals(d, y)
+	return a.Cmp(b)
+}
+func (d Decimal) Add(y Decimal) Decimal {
+	var a, b, s = alignDecimals(d, y)
+	return decimal(new(big.Int).Add(a, b), s)
+}
+func (d Decimal) Sub(y Decimal) Decimal {
+	var a, b, s = alignDecimals(d, y)
+	return decimal(new(big.Int).Sub(a, b), s)
+}
+func (d Decimal) Mul(y Decimal) Decimal {
+	var a, sa = d()
+	var b, sb = y()
+	return decimal(new(big.Int).Mul(a, b), sa+sb)
+}
+
+// Quo divides d by y and rounds the quotient to scale digits after the point.
+// Like big Rat, a divisor of zero panics.
+func (d Decimal) Quo(y Decimal, scale int, mode RoundingMode) Decimal {
+	return roundRat(new(big.Rat).Quo(d.rat(), y.rat()), scale, mode)
+}
+
+// Ratio returns the exact value of the decimal as ratio
+func (d Decimal) Ratio() Ratio {
+	return wrap(ratPool.Get().(*big.Rat).Set(d.rat())).(Ratio)
+}
+func (d Decimal) rat() *big.Rat {
+	var m, s = d()
+	return new(big.Rat).SetFrac(m, pow10(s))
+}
+
+// Decimal rounds the ratio to scale digits after the point
+func (r Ratio) Decimal(scale int, mode RoundingMode) Decimal {
+	return roundRat(r(), scale, mode)
+}
+
+// NewDecimal allocates the decimal mantissa * 10^-scale
+func NewDecimal(mantissa Integer, scale int) Decimal {
+	if scale < 0 {
+		return decimal(new(big.Int).Mul(mantissa(), pow10(-scale)), 0)
+	}
+	return decimal(new(big.Int).Set(mantissa()), scale)
+}
+
+// ParseDecimal parses locale neutral decimals like "1234.50", "-0.5", or
+// "+7". Neither exponents, nor thousands separators are accepted. The scale
+// is the number of digits after the point. If parsing fails, the second
+// return value is false.
+func ParseDecimal(s string) (Decimal, bool) {
+	var digits = s
+	if len(digits) > 0 && (digits[0] == '-' || digits[0] == '+') {
+		digits = digits[1:]
+	}
+	var scale = 0
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		scale = len(digits) - i - 1
+		digits = digits[:i] + digits[i+1:]
+	}
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return nil, false
+	}
+	var m, _ = new(big.Int).SetString(digits, 10)
+	if s[0] == '-' {
+		m.Neg(m)
+	}
+	return decimal(m, scale), true
+}
+
+// encloses mantissa and scale, the mantissa is taken from the pool
+func decimal(m *big.Int, scale int) Decimal {
+	var i = intPool.Get().(*big.Int).Set(m)
+	return func() (*big.Int, int) { return i, scale }
+}
+
+// mantissas of both decimals at their common scale
+func alignDecimals(x, y Decimal) (*big.Int, *big.Int, int) {
+	var a, sa = x()
+	var b, sb = y()
+	switch {
+	case sa < sb:
+		return new(big.Int).Mul(a, pow10(sb-sa)), b, sb
+	case sb < sa:
+		return a, new(big.Int).Mul(b, pow10(sa-sb)), sa
+	}
+	return a, b, sa
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// rounds a ratio to scale digits after the point
+func roundRat(r *big.Rat, scale int, mode RoundingMode) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	var num = new(big.Int).Mul(r.Num(), pow10(scale))
+	var q, rem = new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Sign() != 0 && mode != DOWN {
+		// compare twice the remainder to the denominator, to tell if the
+		// quotient is below, at, or above half way
+		var half = new(big.Int).Lsh(new(big.Int).Abs(rem), 1).Cmp(r.Denom())
+		if half > 0 || (half == 0 && (mode == HALF_UP || q.Bit(0) == 1)) {
+			if num.Sign() < 0 {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+	return decimal(q, scale)
+}
+
+// exactDecimal converts the ratio to a decimal, if its denominator has no
+// other prime factors than two and five. The scale is the least one
+// representing the ratio.
+func exactDecimal(r *big.Rat) (Decimal, bool) {
+	var d = new(big.Int).Set(r.Denom())
+	var scale = 0
+	for _, p := range []int64{2, 5} {
+		var n, m, p = 0, new(big.Int), big.NewInt(p)
+		for m.Mod(d, p).Sign() == 0 {
+			d.Quo(d, p)
+			n++
+		}
+		if n > scale {
+			scale = n
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return nil, false
+	}
+	return roundRat(r, scale, DOWN), true
+}
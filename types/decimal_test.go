@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func parseDecimal(s string) Decimal {
+	d, ok := ParseDecimal(s)
+	if !ok {
+		panic("can not parse decimal: " + s)
+	}
+	return d
+}
+
+// expected results are passed as string and parsed to the expected type.
+// Bools expect "true", or "false".
+func decimalExpected(typ ValueType, s string) Evaluable {
+	switch typ {
+	case RATIONAL:
+		return parseRatio(s)
+	case INTEGER:
+		return Value(s)
+	case BOOL:
+		return Value(s == "true")
+	}
+	return parseDecimal(s)
+}
+
+// function type, to be passed in the test slug
+type decimalTestFunc func(a, b Decimal) Evaluable
+
+// operands are parsed as decimals, expected decimals need to match the scale
+// as well
+var decimalTests = []struct {
+	a, b  string
+	typ   ValueType
+	exp   string
+	opStr string
+	op    decimalTestFunc
+}{
+	{"1234.50", "0", INTEGER, "123450", "Mantissa", func(a, b Decimal) Evaluable { return a.Mantissa() }},
+	{"1234.50", "0", INTEGER, "2", "Scale", func(a, b Decimal) Evaluable { return NewInteger(int64(a.Scale())) }},
+	{"0", "0", DECIMAL, "-0.005", "NewDecimal", func(a, b Decimal) Evaluable { return NewDecimal(NewInteger(-5), 3) }},
+	{"0", "0", DECIMAL, "500", "NewDecimal negative scale", func(a, b Decimal) Evaluable { return NewDecimal(NewInteger(5), -2) }},
+	{"1.5", "0.25", DECIMAL, "1.75", "Add", func(a, b Decimal) Evaluable { return a.Add(b) }},
+	{"1.50", "1.00", DECIMAL, "2.50", "Add keeps scale", func(a, b Decimal) Evaluable { return a.Add(b) }},
+	{"1.5", "2.25", DECIMAL, "-0.75", "Sub", func(a, b Decimal) Evaluable { return a.Sub(b) }},
+	{"1.5", "0.25", DECIMAL, "0.375", "Mul", func(a, b Decimal) Evaluable { return a.Mul(b) }},
+	{"10", "3", DECIMAL, "3.33", "Quo", func(a, b Decimal) Evaluable { return a.Quo(b, 2, HALF_EVEN) }},
+	{"2", "3", DECIMAL, "0.67", "Quo up", func(a, b Decimal) Evaluable { return a.Quo(b, 2, HALF_UP) }},
+	{"2", "3", DECIMAL, "0.66", "Quo down", func(a, b Decimal) Evaluable { return a.Quo(b, 2, DOWN) }},
+	{"1.50", "1.5", INTEGER, "0", "Cmp", func(a, b Decimal) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{"-1.5", "1.49", INTEGER, "-1", "Cmp less", func(a, b Decimal) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{"1.50", "0", DECIMAL, "-1.50", "Neg", func(a, b Decimal) Evaluable { return a.Neg() }},
+	{"-1.50", "0", DECIMAL, "1.50", "Abs", func(a, b Decimal) Evaluable { return a.Abs() }},
+	{"1.5", "0", DECIMAL, "1.500", "Rescale up", func(a, b Decimal) Evaluable { return a.Rescale(3, DOWN) }},
+	{"2.345", "0", DECIMAL, "2.34", "half even down", func(a, b Decimal) Evaluable { return a.Rescale(2, HALF_EVEN) }},
+	{"2.355", "0", DECIMAL, "2.36", "half even up", func(a, b Decimal) Evaluable { return a.Rescale(2, HALF_EVEN) }},
+	{"2.3451", "0", DECIMAL, "2.35", "half even above", func(a, b Decimal) Evaluable { return a.Rescale(2, HALF_EVEN) }},
+	{"2.345", "0", DECIMAL, "2.35", "half up", func(a, b Decimal) Evaluable { return a.Rescale(2, HALF_UP) }},
+	{"-2.345", "0", DECIMAL, "-2.35", "half up negative", func(a, b Decimal) Evaluable { return a.Rescale(2, HALF_UP) }},
+	{"-2.5", "0", DECIMAL, "-2", "half even negative", func(a, b Decimal) Evaluable { return a.Rescale(0, HALF_EVEN) }},
+	{"2.349", "0", DECIMAL, "2.34", "down", func(a, b Decimal) Evaluable { return a.Rescale(2, DOWN) }},
+	{"-2.349", "0", DECIMAL, "-2.34", "down negative", func(a, b Decimal) Evaluable { return a.Rescale(2, DOWN) }},
+	{"0.75", "0", RATIONAL, "3/4", "Ratio", func(a, b Decimal) Evaluable { return a.Ratio() }},
+	{"0", "0", DECIMAL, "0.3333", "from Ratio", func(a, b Decimal) Evaluable { return newRatio(1, 3).Decimal(4, HALF_EVEN) }},
+	{"0", "0", DECIMAL, "-0.13", "from Ratio up", func(a, b Decimal) Evaluable { return newRatio(-1, 8).Decimal(2, HALF_UP) }},
+	{"12.34", "0", DECIMAL, "12.34", "round trip", func(a, b Decimal) Evaluable { return a.Ratio().Decimal(2, DOWN) }},
+	{"2.00", "0", BOOL, "true", "IsInt", func(a, b Decimal) Evaluable { return Value(a.IsInt()) }},
+	{"2.01", "0", BOOL, "false", "IsInt fraction", func(a, b Decimal) Evaluable { return Value(a.IsInt()) }},
+	{"1.25", "0", DECIMAL, "3.25", "Add Integer", func(a, b Decimal) Evaluable { r, _ := Add(a, NewInteger(2)); return r }},
+	{"0.10", "0", DECIMAL, "0.30", "Mul Integer", func(a, b Decimal) Evaluable { r, _ := Mul(NewInteger(3), a); return r }},
+	{"1.00", "0", DECIMAL, "0.125", "Quo exact", func(a, b Decimal) Evaluable { r, _ := Quo(a, NewInteger(8)); return r }},
+	{"1.00", "0", RATIONAL, "1/3", "Quo inexact", func(a, b Decimal) Evaluable { r, _ := Quo(a, NewInteger(3)); return r }},
+	{"0.5", "0", RATIONAL, "5/6", "Add Ratio", func(a, b Decimal) Evaluable { r, _ := Add(a, newRatio(1, 3)); return r }},
+	{"0.5", "0", INTEGER, "0", "Cmp dispatch", func(a, b Decimal) Evaluable { r, _ := Cmp(a, newRatio(1, 2)); return r }},
+	{"0.5", "0", DECIMAL, "1", "Collect", func(a, b Decimal) Evaluable { return Collect(NewInteger(1), a).(ArrayList).Values()[0] }},
+}
+
+func TestDecimal(t *testing.T) {
+	for n, test := range decimalTests {
+		var res = test.op(parseDecimal(test.a), parseDecimal(test.b))
+		var exp = decimalExpected(test.typ, test.exp)
+		testValue(t, n, test.opStr, res, test.typ, exp)
+		if d, ok := res.(Decimal); ok && d.Scale() != exp.(Decimal).Scale() {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got scale: %d expected: %d",
+				n, test.opStr, d.Scale(), exp.(Decimal).Scale()))
+		}
+	}
+}
+
+// decimals parse to mantissa and scale, exponents and separators are rejected
+var decimalParseTests = []struct {
+	s        string
+	ok       bool
+	mantissa int64
+	scale    int
+}{
+	{"1234.50", true, 123450, 2},
+	{"-0.05", true, -5, 2},
+	{"+7", true, 7, 0},
+	{".5", true, 5, 1},
+	{"5.", true, 5, 0},
+	{"1e3", false, 0, 0},
+	{"1,234.50", false, 0, 0},
+	{"-.", false, 0, 0},
+}
+
+func TestParseDecimal(t *testing.T) {
+	for n, test := range decimalParseTests {
+		var d, ok = ParseDecimal(test.s)
+		if ok != test.ok {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: parsing %q got: %v expected: %v", n, test.s, ok, test.ok))
+			continue
+		}
+		if ok {
+			testValue(t, n, "ParseDecimal "+test.s, d, DECIMAL, NewDecimal(NewInteger(test.mantissa), test.scale))
+			if d.Scale() != test.scale {
+				(*t).Fail()
+				(*t).Log(fmt.Sprintf("failed Test Nr. %d: parsing %q got scale: %d expected: %d", n, test.s, d.Scale(), test.scale))
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"math/big"
+)
+
+//////////////////////////////////////////////////////////////////////////
+//// EQUALITY & HASHING
+///
+// all values are closures, which go can neither compare, nor hash. Equal and
+// Hash compare values structurally instead: two values are equal, if they are
+// of the same type and represent the same content. Numbers are equal, if their
+// exact values are, regardless of precision, or scale (1.50 equals 1.5).
+// Ordered collections are equal, if all their elements are equal in order,
+// tables need to be of the same shape as well. Maps and sets are equal, if
+// they contain equal elements in any order. Plain values, as returned by the
+// native conversion, are considered integers. Values that are equal allways
+// share the same hash.
+
+// Equal determines if both values are structurally equal
+func Equal(a, b Evaluable) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if scalarType(a) != scalarType(b) {
+		return false
+	}
+	switch x := a.(type) {
+	case BitFlag: // flags are compared by their bits
+	case Pair:
+		if y, ok := b.(Pair); ok {
+			return Equal(x.Key(), y.Key()) && Equal(x.Value(), y.Value())
+		}
+		return false
+	case Mapped:
+		if y, ok := b.(Mapped); ok {
+			return sameElements(mapEntries(x), mapEntries(y))
+		}
+		return false
+	case DeDublicated:
+		if y, ok := b.(DeDublicated); ok {
+			return sameElements(x.Values(), y.Values())
+		}
+		return false
+	case LabeledTable:
+		if y, ok := b.(LabeledTable); ok {
+			return sameSequence(labeledEntries(x), labeledEntries(y))
+		}
+		return false
+	case Tabular: // tables of differing shape differ
+		if y, ok := b.(Tabular); ok {
+			return sameSequence(shapeOf(x), shapeOf(y)) && sameSequence(x.Values(), y.Values())
+		}
+		return false
+	case Collected:
+		if y, ok := b.(Collected); ok {
+			return sameSequence(x.Values(), y.Values())
+		}
+		return false
+	}
+	return bytes.Equal(canonical(a), canonical(b))
+}
+
+// Hash returns a hash of the values type and content, that is equal for all
+// values that are equal.
+func Hash(v Evaluable) uint64 {
+	if v == nil {
+		return hashOf(EMPTY, nil)
+	}
+	switch x := v.(type) {
+	case BitFlag:
+	case Pair:
+		return hashOf(PAIR, nil, Hash(x.Key()), Hash(x.Value()))
+	case Mapped:
+		return hashOf(x.Type(), nil, unordered(mapEntries(x)))
+	case DeDublicated:
+		return hashOf(x.Type(), nil, unordered(x.Values()))
+	case LabeledTable:
+		var hs = []uint64{}
+		for _, e := range labeledEntries(x) {
+			hs = append(hs, Hash(e))
+		}
+		return hashOf(x.Type(), nil, hs...)
+	case Tabular:
+		var hs = []uint64{}
+		for _, e := range append(shapeOf(x), x.Values()...) {
+			hs = append(hs, Hash(e))
+		}
+		return hashOf(x.Type(), nil, hs...)
+	case Collected:
+		var hs = []uint64{}
+		for _, e := range x.Values() {
+			hs = append(hs, Hash(e))
+		}
+		return hashOf(x.Type(), nil, hs...)
+	}
+	return hashOf(scalarType(v), canonical(v))
+}
+
+// hashes the type, followed by the content and the hashes of nested values
+func hashOf(t ValueType, content []byte, hs ...uint64) uint64 {
+	var h = fnv.New64a()
+	var buf = make([]byte, 8)
+	binary.BigEndian.PutUint32(buf, uint32(t))
+	h.Write(buf[:4])
+	h.Write(content)
+	for _, x := range hs {
+		binary.BigEndian.PutUint64(buf, x)
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
+
+// order independent hash of a collection of elements, summed up, so that
+// elements contained more than once still count.
+func unordered(v []Evaluable) (r uint64) {
+	for _, e := range v {
+		r += Hash(e)
+	}
+	return r
+}
+
+// canonical returns a byte representation of scalar values, that is equal
+// for all equal values of the same type. Numbers are represented by their
+// exact value, flags by their bits, symbolic values by their content.
+func canonical(v Evaluable) []byte {
+	switch x := v.(type) {
+	case ValueType:
+		return []byte(new(big.Int).SetUint64(uint64(x)).String())
+	case TokenType:
+		return []byte(new(big.Int).SetUint64(uint64(x)).String())
+	case NodeType:
+		return []byte(new(big.Int).SetUint64(uint64(x)).String())
+	case Float:
+		if x().IsInf() {
+			return []byte(x.String())
+		}
+	case Text:
+		return x.Serialize()
+	case Bytes:
+		return x.Serialize()
+	}
+	if i, ok := bigIntOf(v); ok {
+		return []byte(i.String())
+	}
+	if r, ok := bigRatOf(v); ok {
+		return []byte(r.String())
+	}
+	return v.Serialize()
+}
+
+// pairs of all keys and values a map contains
+func mapEntries(m Mapped) []Evaluable {
+	var r = []Evaluable{}
+	var keys, values = m.Keys(), m.Values()
+	for i := range keys {
+		r = append(r, pairFromValues(keys[i], values[i]))
+	}
+	return r
+}
+
+// elements are equal in order
+func sameSequence(a, b []Evaluable) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// elements are equal in any order, each element of a is matched with one
+// distinct element of b
+func sameElements(a, b []Evaluable) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var buckets = hashBuckets(b)
+	for _, e := range a {
+		var h = Hash(e)
+		var found = false
+		for i, x := range buckets[h] {
+			if Equal(e, x) {
+				buckets[h] = append(buckets[h][:i], buckets[h][i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// values sorted into buckets by their hash
+func hashBuckets(v []Evaluable) map[uint64][]Evaluable {
+	var r = map[uint64][]Evaluable{}
+	for _, e := range v {
+		var h = Hash(e)
+		r[h] = append(r[h], e)
+	}
+	return r
+}
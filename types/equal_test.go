@@ -0,0 +1,144 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+// equal values need to share the same hash
+var equalTests = []struct {
+	opStr    string
+	operands func() (a, b Evaluable)
+	exp      bool
+}{
+	{"Integer", func() (a, b Evaluable) { return NewInteger(42), NewInteger(42) }, true},
+	{"Integer differs", func() (a, b Evaluable) { return NewInteger(42), NewInteger(43) }, false},
+	{"plain value", func() (a, b Evaluable) { return Value(7), NewInteger(7) }, true},
+	{"types differ", func() (a, b Evaluable) { return NewInteger(1), NewUint(1) }, false},
+	{"Text", func() (a, b Evaluable) { return NewText("abc"), NewText("abc") }, true},
+	{"Text and Bytes", func() (a, b Evaluable) { return NewText("abc"), NewBytes([]byte("abc")) }, false},
+	{"Ratio", func() (a, b Evaluable) { return newRatio(2, 4), newRatio(1, 2) }, true},
+	{"Decimal scale", func() (a, b Evaluable) { return parseDecimal("1.50"), parseDecimal("1.5") }, true},
+	{"Float precision", func() (a, b Evaluable) { return NewFloat(0.5), NewFloat(0.5).SetPrec(200) }, true},
+	{"Bool", func() (a, b Evaluable) { return Value(true), Value(true) }, true},
+	{"flags", func() (a, b Evaluable) { return NewFlag(1, 3), NewFlag(3, 1) }, true},
+	{"token types", func() (a, b Evaluable) { return TOKEN_HEADER, TOKEN_HEADER.Flag() }, true},
+	{"Pair", func() (a, b Evaluable) { return Value("a", 1), Value("a", 1) }, true},
+	{"Pair differs", func() (a, b Evaluable) { return Value("a", 1), Value("a", 2) }, false},
+	{"nil", func() (a, b Evaluable) { return nil, nil }, true},
+	{"nil and zero", func() (a, b Evaluable) { return nil, NewInteger(0) }, false},
+	{"List", func() (a, b Evaluable) {
+		return NewArrayList(NewInteger(1), NewText("x")), NewArrayList(NewInteger(1), NewText("x"))
+	}, true},
+	{"List order", func() (a, b Evaluable) {
+		return NewArrayList(NewInteger(1), NewInteger(2)), NewArrayList(NewInteger(2), NewInteger(1))
+	}, false},
+	{"List nested", func() (a, b Evaluable) {
+		return NewArrayList(NewArrayList(NewInteger(1)), NewText("x")), NewArrayList(NewArrayList(NewInteger(1)), NewText("x"))
+	}, true},
+	{"Map order", func() (a, b Evaluable) {
+		return newHashMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2)),
+			newHashMap().Put(NewText("b"), NewInteger(2)).Put(NewText("a"), NewInteger(1))
+	}, true},
+	{"Map differs", func() (a, b Evaluable) {
+		return newHashMap().Put(NewText("a"), NewInteger(1)), newHashMap().Put(NewText("a"), NewInteger(2))
+	}, false},
+	{"Set", func() (a, b Evaluable) {
+		return newHashSet(NewInteger(1), NewText("x")), newHashSet(NewText("x"), NewInteger(1))
+	}, true},
+}
+
+func TestEqual(t *testing.T) {
+	for n, test := range equalTests {
+		var a, b = test.operands()
+		if Equal(a, b) != test.exp || (test.exp && Hash(a) != Hash(b)) {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s equal: %v same hash: %v expected: %v",
+				n, test.opStr, Equal(a, b), Hash(a) == Hash(b), test.exp))
+		}
+	}
+}
+
+// hashed collections look up their elements by equality. Results of more
+// than one value are returned as list.
+var hashedTests = []struct {
+	opStr string
+	typ   ValueType
+	exp   interface{}
+	op    func() Evaluable
+}{
+	{"Map Get", LIST, []interface{}{"list", true}, func() Evaluable {
+		var m = newHashMap().Put(NewArrayList(NewInteger(1)), NewText("list"))
+		v, ok := m.(HashMap).Get(NewArrayList(NewInteger(1)))
+		return NewArrayList(v, Value(ok))
+	}},
+	{"Map Remove", INTEGER, 1, func() Evaluable {
+		var m = newHashMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+		return NewInteger(int64(m.Remove(NewText("a")).Size()))
+	}},
+	{"Map overwrite", LIST, []interface{}{1, "b"}, func() Evaluable {
+		var m = newHashMap().Put(Value(1), NewText("a")).Put(NewInteger(1), NewText("b"))
+		v, _ := m.(HashMap).Get(NewInteger(1))
+		return NewArrayList(NewInteger(int64(m.Size())), v)
+	}},
+	{"BidiMap", LIST, []interface{}{2, true, 2}, func() Evaluable {
+		var m = newHashBidiMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+		v, ok := m.(HashBidiMap).Get(NewText("b"))
+		return NewArrayList(v, Value(ok), NewInteger(int64(m.Size())))
+	}},
+	{"BidiMap unique values", LIST, []interface{}{1, "b"}, func() Evaluable {
+		var m = newHashBidiMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(1))
+		k, _ := getKeyFromMap(m.(HashBidiMap)(), NewInteger(1))
+		return NewArrayList(NewInteger(int64(m.Size())), k)
+	}},
+	{"BidiMap GetKey", LIST, []interface{}{"a", true, false, 2, []interface{}{"a", "b"}, []interface{}{3, 2}}, func() Evaluable {
+		var m = newHashBidiMap().Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+		m.Put(NewText("a"), NewInteger(3))
+		_, old := getKeyFromMap(m.(HashBidiMap)(), NewInteger(1))
+		k, ok := getKeyFromMap(m.(HashBidiMap)(), NewInteger(3))
+		return NewArrayList(k, Value(ok), Value(old), NewInteger(int64(m.Size())),
+			NewArrayList(m.Keys()...), NewArrayList(m.Values()...))
+	}},
+	{"Set Contains", LIST, []interface{}{true, false}, func() Evaluable {
+		var s = newHashSet(NewText("x"), NewArrayList(NewInteger(1)))
+		return NewArrayList(Value(s.Contains(NewArrayList(NewInteger(1)))), Value(s.Contains(NewText("y"))))
+	}},
+	{"Set dedublicates", INTEGER, 1, func() Evaluable {
+		return NewInteger(int64(newHashSet(NewInteger(1), Value(1)).Size()))
+	}},
+	{"Set Remove", LIST, []interface{}{1, false}, func() Evaluable {
+		var s = newHashSet(NewText("x"), NewText("y")).Remove(NewText("x"))
+		return NewArrayList(NewInteger(int64(s.Size())), Value(s.Contains(NewText("x"))))
+	}},
+	{"Set String", TEXT, "a\nb\n", func() Evaluable { return NewText(newHashSet(NewText("b"), NewText("a")).String()) }},
+	{"collisions", LIST, []interface{}{3, 2, 2, false, 1, []interface{}{"b"}}, func() Evaluable {
+		var m = newHashMap()
+		putToBucket(m(), uint64(7), pairFromValues(NewText("a"), NewInteger(1)))
+		putToBucket(m(), uint64(7), pairFromValues(NewText("b"), NewInteger(2)))
+		putToBucket(m(), uint64(7), pairFromValues(NewText("a"), NewInteger(3)))
+		a, _ := bucketOf(m(), uint64(7)).find(NewText("a"))
+		b, _ := bucketOf(m(), uint64(7)).find(NewText("b"))
+		var size = m.Size()
+		removeFromBucket(m(), uint64(7), func(p Pair) bool { return Equal(p.Key(), NewText("a")) })
+		_, ok := bucketOf(m(), uint64(7)).find(NewText("a"))
+		return NewArrayList(a.Value(), b.Value(), NewInteger(int64(size)), Value(ok),
+			NewInteger(int64(m.Size())), NewArrayList(m.Keys()...))
+	}},
+	{"collisions shared", LIST, []interface{}{[]interface{}{"a", "b"}, []interface{}{"a"}, true}, func() Evaluable {
+		var a = newHashSet(NewText("a"))
+		putToBucket(a(), Hash(NewText("a")), pairFromValues(NewText("b"), NewText("b")))
+		var b = copyCollection(a).(HashSet)
+		removeFromBucket(b(), Hash(NewText("a")), func(p Pair) bool { return Equal(p.Key(), NewText("b")) })
+		return NewArrayList(NewArrayList(a.Values()...), NewArrayList(b.Values()...), Value(b.Contains(NewText("a"))))
+	}},
+	{"List Contains", LIST, []interface{}{true, false}, func() Evaluable {
+		var l = NewArrayList(NewArrayList(NewText("x")), newRatio(1, 2))
+		return NewArrayList(Value(l.Contains(NewArrayList(NewText("x")), newRatio(2, 4))), Value(l.Contains(NewText("x"))))
+	}},
+}
+
+func TestHashed(t *testing.T) {
+	for n, test := range hashedTests {
+		testValue(t, n, test.opStr, test.op(), test.typ, nativeValue(test.exp))
+	}
+}
@@ -0,0 +1,229 @@
+package types
+
+import (
+	"math/big"
+)
+
+//////////////////////////////////////////////////////////////////////////
+//// BIT FLAG
+///
+// a bit flag is a set of small non negative integers, each represented by the
+// bit at its position. As collection its values are the positions of all set
+// bits in ascending order, which makes flags compact sets of value-, token-
+// and node types. All methods return fresh instances.
+func (f BitFlag) Eval() Evaluable { return f }
+
+// uses byte method of contained big int
+func (f BitFlag) Serialize() []byte { return f().Bytes() }
+
+// returns Flag converted to string on base two
+func (f BitFlag) String() string { return f().Text(2) }
+
+// returns pure type Flag
+func (f BitFlag) Type() ValueType { return FLAG }
+
+func (f BitFlag) Empty() bool { return f().Sign() == 0 }
+
+// Bool encodes the flag according to the passed bool type, see EncodeBools.
+// NATIVE is true, if any bit is set. Encodings the flag doesn't fit into,
+// return nil.
+func (f BitFlag) Bool(b BoolType) (r Evaluable) {
+	switch b {
+	case NATIVE:
+		r = Value(!f.Empty())
+	case BIT_FLAG:
+		r = f
+	default:
+		r, _ = EncodeBools(b, bitsToBools(f())...)
+	}
+	return r
+}
+
+// number of set bits
+func (f BitFlag) Size() (n int) {
+	for _, w := range f().Bits() {
+		for ; w != 0; w &= w - 1 {
+			n++
+		}
+	}
+	return n
+}
+func (f BitFlag) Clear() Collected { return newFlag() }
+
+// positions of all set bits in ascending order
+func (f BitFlag) Positions() []int {
+	var r = []int{}
+	for i := 0; i < f().BitLen(); i++ {
+		if f().Bit(i) == 1 {
+			r = append(r, i)
+		}
+	}
+	return r
+}
+func (f BitFlag) Values() []Evaluable {
+	var r = []Evaluable{}
+	for _, i := range f.Positions() {
+		r = append(r, NewInteger(int64(i)))
+	}
+	return r
+}
+func (f BitFlag) Interfaces() []interface{} {
+	var r = []interface{}{}
+	for _, i := range f.Positions() {
+		r = append(r, i)
+	}
+	return r
+}
+func (f BitFlag) Contains(i int) bool { return i >= 0 && f().Bit(i) == 1 }
+
+// Shift sets the bit at digit to boolVal. Positive digits count from the
+// least significant bit, starting at one, negative digits from the most
+// significant bit, starting at minus one. Digit zero leaves the flag as it is.
+func (f BitFlag) Shift(boolVal uint, digit int) Flagged {
+	var i = digit - 1
+	if digit < 0 {
+		i = f().BitLen() + digit
+	}
+	if digit == 0 || i < 0 {
+		return f.copy()
+	}
+	return flagOf(new(big.Int).SetBit(f(), i, boolVal&1))
+}
+
+// Add is strictly additive. Flags, token- and node types get joined with the
+// flag. Bools get appended above the most significant bit, if true. All
+// other natural numbers set the bit at their position.
+func (f BitFlag) Add(v ...Evaluable) Flagged {
+	var r = new(big.Int).Set(f())
+	for _, v := range v {
+		switch x := v.(type) {
+		case BitFlag:
+			r.Or(r, x())
+		case TokenType:
+			r.Or(r, x.Flag()())
+		case NodeType:
+			r.Or(r, x.Flag()())
+		case Bool:
+			if x.Native() {
+				r.SetBit(r, r.BitLen(), 1)
+			}
+		default:
+			if i, ok := bigIntOf(v); ok && i.Sign() >= 0 && i.IsInt64() {
+				r.SetBit(r, int(i.Int64()), 1)
+			}
+		}
+	}
+	return flagOf(r)
+}
+
+// Remove clears the bit at the passed position
+func (f BitFlag) Remove(i int) Flagged {
+	if i < 0 {
+		return f.copy()
+	}
+	return flagOf(new(big.Int).SetBit(f(), i, 0))
+}
+
+// Match determines if all bits set in x, are set in f as well
+func (f BitFlag) Match(x Flagged) bool {
+	var b = flagBits(x)
+	return new(big.Int).AndNot(b, f()).Sign() == 0
+}
+
+//// SET OPERATIONS
+func (f BitFlag) Union(x Flagged) BitFlag {
+	return flagOf(new(big.Int).Or(f(), flagBits(x)))
+}
+func (f BitFlag) Intersection(x Flagged) BitFlag {
+	return flagOf(new(big.Int).And(f(), flagBits(x)))
+}
+func (f BitFlag) Difference(x Flagged) BitFlag {
+	return flagOf(new(big.Int).AndNot(f(), flagBits(x)))
+}
+
+// Or returns the union of the flag and the bits of the passed value, which is
+// either flagged, or a natural number.
+//
+// Deprecated: use Union.
+func (f BitFlag) Or(v Evaluable) BitFlag {
+	if x, ok := v.(Flagged); ok {
+		return f.Union(x)
+	}
+	if i, ok := bigIntOf(v); ok {
+		return flagOf(new(big.Int).Or(f(), i))
+	}
+	return f
+}
+
+// Iterator returns an iterator over the positions of all set bits
+func (f BitFlag) Iterator() *FlagIterator {
+	return &FlagIterator{pos: f.Positions(), cur: -1}
+}
+
+func (f BitFlag) copy() BitFlag { return flagOf(new(big.Int).Set(f())) }
+
+// NewFlag allocates a flag with the bits at the passed positions set
+func NewFlag(pos ...int) BitFlag {
+	var r = intPool.Get().(*big.Int).SetInt64(0)
+	for _, i := range pos {
+		if i >= 0 {
+			r.SetBit(r, i, 1)
+		}
+	}
+	return flagOf(r)
+}
+func newFlag() BitFlag { return flagOf(intPool.Get().(*big.Int).SetInt64(0)) }
+
+// wraps the int as flag, negative values are no valid flags and yield an
+// empty one.
+func flagOf(i *big.Int) BitFlag {
+	if i.Sign() < 0 {
+		i.SetInt64(0)
+	}
+	return func() *big.Int { return i }
+}
+
+// bits of any flagged value
+func flagBits(x Flagged) *big.Int {
+	if f, ok := x.(BitFlag); ok {
+		return f()
+	}
+	if f, ok := x.Bool(BIT_FLAG).(BitFlag); ok {
+		return f()
+	}
+	return new(big.Int)
+}
+
+//////////////////////////////////////////////////////////////////////////
+//// FLAG ITERATOR
+///
+// iterates over the positions of the set bits in ascending order. Value and
+// Index both return the current position.
+type FlagIterator struct {
+	pos []int
+	cur int
+}
+
+func (i *FlagIterator) Next() bool {
+	if i.cur < len(i.pos) {
+		i.cur++
+	}
+	return i.cur < len(i.pos)
+}
+func (i *FlagIterator) Prev() bool {
+	if i.cur >= 0 {
+		i.cur--
+	}
+	return i.cur >= 0
+}
+func (i *FlagIterator) Value() Evaluable { return i.Index() }
+func (i *FlagIterator) Index() Integer {
+	if i.cur < 0 || i.cur >= len(i.pos) {
+		return NewInteger(-1)
+	}
+	return NewInteger(int64(i.pos[i.cur]))
+}
+func (i *FlagIterator) Begin()      { i.cur = -1 }
+func (i *FlagIterator) End()        { i.cur = len(i.pos) }
+func (i *FlagIterator) First() bool { i.Begin(); return i.Next() }
+func (i *FlagIterator) Last() bool  { i.End(); return i.Prev() }
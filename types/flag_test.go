@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+)
+
+// bit flags implement flagged as well as collected, the flag iterator moves
+// in both directions
+var (
+	_ Flagged   = BitFlag(nil)
+	_ Collected = BitFlag(nil)
+	_ Reverse   = &FlagIterator{}
+)
+
+// expected flags are passed as positions of the bits set
+type bits []int
+
+func (b bits) value() Evaluable { return NewFlag(b...) }
+
+// function type, to be passed in the test slug
+type flagTestFunc func(a BitFlag) Evaluable
+
+// the operand is passed as positions of the bits set
+var flagTests = []struct {
+	a     []int
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    flagTestFunc
+}{
+	{[]int{0, 2, 5}, FLAG, bits{0, 2, 5}, "NewFlag", func(a BitFlag) Evaluable { return a }},
+	{[]int{0, 2, 70}, INTEGER, 3, "Size", func(a BitFlag) Evaluable { return NewInteger(int64(a.Size())) }},
+	{[]int{}, BOOL, true, "Empty", func(a BitFlag) Evaluable { return Value(a.Empty()) }},
+	{[]int{1}, BOOL, false, "Empty set", func(a BitFlag) Evaluable { return Value(a.Empty()) }},
+	{[]int{1, 2}, BOOL, true, "Clear", func(a BitFlag) Evaluable { return Value(a.Clear().Empty()) }},
+	{[]int{5, 0, 2}, LIST, []interface{}{0, 2, 5}, "Values", func(a BitFlag) Evaluable { return NewArrayList(a.Values()...) }},
+	{[]int{3}, INTEGER, 3, "Values type", func(a BitFlag) Evaluable { return a.Values()[0] }},
+	{[]int{3}, BOOL, true, "Contains", func(a BitFlag) Evaluable { return Value(a.Contains(3)) }},
+	{[]int{3}, BOOL, false, "Contains unset", func(a BitFlag) Evaluable { return Value(a.Contains(2)) }},
+	{[]int{0, 1}, FLAG, bits{0, 1, 3}, "Union", func(a BitFlag) Evaluable { return a.Union(NewFlag(1, 3)) }},
+	{[]int{0, 1, 3}, FLAG, bits{1, 3}, "Intersection", func(a BitFlag) Evaluable { return a.Intersection(NewFlag(1, 3, 4)) }},
+	{[]int{0, 1, 3}, FLAG, bits{0, 3}, "Difference", func(a BitFlag) Evaluable { return a.Difference(NewFlag(1)) }},
+	{[]int{0, 1}, FLAG, bits{0, 1, 3}, "Or", func(a BitFlag) Evaluable { return a.Or(NewFlag(1, 3)) }},
+	{[]int{0}, FLAG, bits{0, 1, 2}, "Or integer", func(a BitFlag) Evaluable { return a.Or(NewInteger(6)) }},
+	{[]int{0}, FLAG, bits{0}, "immutable", func(a BitFlag) Evaluable { a.Union(NewFlag(1)); return a }},
+	{[]int{0, 1, 3}, BOOL, true, "Match subset", func(a BitFlag) Evaluable { return Value(a.Match(NewFlag(0, 3))) }},
+	{[]int{0}, BOOL, true, "Match empty", func(a BitFlag) Evaluable { return Value(a.Match(NewFlag())) }},
+	{[]int{0, 1}, BOOL, false, "Match no subset", func(a BitFlag) Evaluable { return Value(a.Match(NewFlag(0, 2))) }},
+	{[]int{0, 1, 3}, FLAG, bits{0, 3}, "Remove", func(a BitFlag) Evaluable { return a.Remove(1).(BitFlag) }},
+	{[]int{0}, FLAG, bits{0}, "Remove unset", func(a BitFlag) Evaluable { return a.Remove(4).(BitFlag) }},
+	{[]int{}, FLAG, bits{2}, "Shift set", func(a BitFlag) Evaluable { return a.Shift(1, 3).(BitFlag) }},
+	{[]int{0, 2}, FLAG, bits{2}, "Shift clear", func(a BitFlag) Evaluable { return a.Shift(0, 1).(BitFlag) }},
+	{[]int{0, 3}, FLAG, bits{0}, "Shift negative", func(a BitFlag) Evaluable { return a.Shift(0, -1).(BitFlag) }},
+	{[]int{1}, FLAG, bits{1}, "Shift zero", func(a BitFlag) Evaluable { return a.Shift(1, 0).(BitFlag) }},
+	{[]int{}, FLAG, bits{0, 2}, "Add positions", func(a BitFlag) Evaluable { return a.Add(NewInteger(2), NewUint(0)).(BitFlag) }},
+	{[]int{0}, FLAG, bits{0, 4}, "Add flags", func(a BitFlag) Evaluable { return a.Add(NewFlag(4)).(BitFlag) }},
+	{[]int{0}, FLAG, bits{0, 1, 2}, "Add bools", func(a BitFlag) Evaluable {
+		return a.Add(Value(true), Value(false), Value(true)).(BitFlag)
+	}},
+	{[]int{1}, FLAG, bits{1}, "Add additive", func(a BitFlag) Evaluable { return a.Add(NewInteger(1)).(BitFlag) }},
+	{[]int{1}, FLAG, bits{1}, "Add negative", func(a BitFlag) Evaluable { return a.Add(NewInteger(-1)).(BitFlag) }},
+	{[]int{}, FLAG, TOKEN_HEADER | TOKEN_EMPHASIS, "token types", func(a BitFlag) Evaluable {
+		return a.Add(TOKEN_HEADER, TOKEN_EMPHASIS).(BitFlag).Bool(TOKEN_TYPE).(TokenType)
+	}},
+	{[]int{}, BOOL, true, "token match", func(a BitFlag) Evaluable {
+		return Value((TOKEN_HEADER | TOKEN_EMPHASIS | TOKEN_LINK).Flag().Match(TOKEN_LINK.Flag()))
+	}},
+	{[]int{}, BOOL, false, "token match unset", func(a BitFlag) Evaluable {
+		return Value((TOKEN_HEADER | TOKEN_EMPHASIS | TOKEN_LINK).Flag().Match(TOKEN_IMAGE.Flag()))
+	}},
+	{[]int{}, BOOL, true, "value types", func(a BitFlag) Evaluable { return Value(NUMERIC.Flag().Match(INTEGER.Flag())) }},
+	{[]int{}, BOOL, false, "value types unset", func(a BitFlag) Evaluable { return Value(NUMERIC.Flag().Match(TEXT.Flag())) }},
+	{[]int{1, 4, 6}, LIST, []interface{}{1, 4, 6}, "Iterator", func(a BitFlag) Evaluable {
+		var r = []Evaluable{}
+		for i := a.Iterator(); i.Next(); {
+			r = append(r, i.Value())
+		}
+		return NewArrayList(r...)
+	}},
+	{[]int{1, 4, 6}, LIST, []interface{}{6, 4, 1}, "Iterator reverse", func(a BitFlag) Evaluable {
+		var r = []Evaluable{}
+		var i = a.Iterator()
+		for ok := i.Last(); ok; ok = i.Prev() {
+			r = append(r, i.Index())
+		}
+		return NewArrayList(r...)
+	}},
+	{[]int{}, BOOL, false, "Iterator empty", func(a BitFlag) Evaluable { return Value(a.Iterator().First()) }},
+	{[]int{0}, INTEGER, 2, "Add dispatch", func(a BitFlag) Evaluable { r, _ := Add(a, NewInteger(1)); return r }},
+}
+
+func TestFlag(t *testing.T) {
+	for n, test := range flagTests {
+		testValue(t, n, test.opStr, test.op(NewFlag(test.a...)), test.typ, nativeValue(test.exp))
+	}
+}
@@ -1,25 +1,116 @@
 package types
 
 import (
+	"fmt"
 	"math/big"
 )
 
 /////////////////////////////////////////////////////////////////////////
 // FLOAT
-type Float ratio
+// floats are backed by big Float. Each instance carries its own precision in
+// bits and rounding mode. Results of arithmetic get the larger precision of
+// both operands and the rounding mode of the receiver. Like big Float,
+// operations that would yield NaN, like the square root of a negative
+// number, panic with big.ErrNaN.
+type Float flt
+
+// precision in bits, floats get allocated with, if none is given explicitly
+const DefaultPrec uint = 64
 
 func (f Float) Eval() Evaluable   { return f }
 func (f Float) Serialize() []byte { return []byte(f.String()) }
-func (f Float) String() string    { return f().FloatString(10) }
-func (f Float) Type() ValueType   { return FLOAT }
-
-func (f Float) Float() Evaluable {
-	f = wrap(floatPool.Get().(*big.Float)).(Float)
-	defer discardRat(f())
-	return wrap(f().Set(f()))
-}
-func (f Float) Ratio() Evaluable {
-	f = wrap(floatPool.Get().(*big.Rat)).(Float)
-	defer discardRat(f())
-	return wrap(f().Set(f()))
+
+// shortest decimal representation, that identifies the value uniquely at its
+// precision
+func (f Float) String() string  { return f().Text('g', -1) }
+func (f Float) Type() ValueType { return FLOAT }
+
+// Format implements fmt.Formatter and accepts all verbs big Float accepts,
+// like %e, %f and %g, including width and precision.
+func (f Float) Format(s fmt.State, ch rune) { f().Format(s, ch) }
+
+// Text converts the float to a string according to the format byte, which is
+// one of 'e', 'E', 'f', 'g', 'G', 'b', 'p', or 'x' and the number of digits.
+func (f Float) Text(format byte, prec int) string { return f().Text(format, prec) }
+
+func (f Float) Prec() uint               { return f().Prec() }
+func (f Float) Mode() big.RoundingMode   { return f().Mode() }
+func (f Float) Acc() big.Accuracy        { return f().Acc() }
+func (f Float) Sign() int                { return f().Sign() }
+func (f Float) IsInf() bool              { return f().IsInf() }
+func (f Float) IsInt() bool              { return f().IsInt() }
+func (f Float) Float64() (float64, bool) { x, acc := f().Float64(); return x, acc == big.Exact }
+
+// copy of the float rounded to the passed precision
+func (f Float) SetPrec(prec uint) Float {
+	return wrap(newFloat(prec, f.Mode()).Set(f())).(Float)
+}
+
+// copy of the float, further operations on which round according to mode
+func (f Float) SetMode(mode big.RoundingMode) Float {
+	return wrap(newFloat(f.Prec(), mode).Set(f())).(Float)
+}
+
+func (f Float) Add(y Float) Float { return wrap(f.result(y).Add(f(), y())).(Float) }
+func (f Float) Sub(y Float) Float { return wrap(f.result(y).Sub(f(), y())).(Float) }
+func (f Float) Mul(y Float) Float { return wrap(f.result(y).Mul(f(), y())).(Float) }
+func (f Float) Quo(y Float) Float { return wrap(f.result(y).Quo(f(), y())).(Float) }
+func (f Float) Cmp(y Float) int   { return f().Cmp(y()) }
+func (f Float) Neg() Float        { return wrap(newFloat(f.Prec(), f.Mode()).Neg(f())).(Float) }
+func (f Float) Abs() Float        { return wrap(newFloat(f.Prec(), f.Mode()).Abs(f())).(Float) }
+func (f Float) Sqrt() Float       { return wrap(newFloat(f.Prec(), f.Mode()).Sqrt(f())).(Float) }
+
+// Ratio returns the exact value of the float as ratio. Infinite floats have
+// no ratio, in which case the second return value is false.
+func (f Float) Ratio() (Ratio, bool) {
+	if f.IsInf() {
+		return nil, false
+	}
+	var r, _ = f().Rat(ratPool.Get().(*big.Rat))
+	return wrap(r).(Ratio), true
+}
+
+// allocates the receiver of an operation on f and y
+func (f Float) result(y Float) *big.Float {
+	var prec = f.Prec()
+	if y.Prec() > prec {
+		prec = y.Prec()
+	}
+	return newFloat(prec, f.Mode())
+}
+
+// NewFloat allocates a float from a native float64 at DefaultPrec, which
+// represents it exactly.
+func NewFloat(x float64) Float {
+	return wrap(newFloat(DefaultPrec, big.ToNearestEven).SetFloat64(x)).(Float)
+}
+
+// ParseFloat parses a decimal representation of a float, like "1.5e-3", with
+// the passed precision and rounding mode.
+func ParseFloat(s string, prec uint, mode big.RoundingMode) (Float, error) {
+	var f, _, err = newFloat(prec, mode).Parse(s, 10)
+	if err != nil {
+		return nil, err
+	}
+	return wrap(f).(Float), nil
+}
+
+// fetches a float from the pool, resetting precision and mode
+func newFloat(prec uint, mode big.RoundingMode) *big.Float {
+	return floatPool.Get().(*big.Float).SetPrec(0).SetPrec(prec).SetMode(mode)
+}
+
+// converts numeric values to big Float. Integers are converted exactly,
+// ratios at a precision of at least 64 bit.
+func bigFloatOf(v Evaluable) (*big.Float, bool) {
+	if f, ok := v.(Float); ok {
+		return f(), true
+	}
+	if i, ok := bigIntOf(v); ok {
+		return new(big.Float).SetInt(i), true
+	}
+	if r, ok := bigRatOf(v); ok {
+		return new(big.Float).SetRat(r), true
+	}
+	return nil, false
 }
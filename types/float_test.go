@@ -0,0 +1,136 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// float operand of the passed precision and rounding mode. Zero precision
+// keeps the precision of float64.
+func floatOperand(x float64, prec uint, mode big.RoundingMode) Float {
+	var f = NewFloat(x).SetMode(mode)
+	if prec > 0 {
+		f = f.SetPrec(prec)
+	}
+	return f
+}
+
+// expected results are passed as float64 and converted to the expected type
+func floatExpected(typ ValueType, x float64) Evaluable {
+	switch typ {
+	case RATIONAL:
+		return Value(x)
+	case INTEGER:
+		return NewInteger(int64(x))
+	case UINT:
+		return NewUint(uint64(x))
+	}
+	return NewFloat(x)
+}
+
+// function type, to be passed in the test slug
+type floatTestFunc func(a, b Float) Evaluable
+
+var floatTests = []struct {
+	a, b  float64
+	prec  uint             // precision of a
+	mode  big.RoundingMode // rounding mode of a
+	typ   ValueType
+	exp   float64
+	opStr string
+	op    floatTestFunc
+}{
+	{6.25, 0, 0, 0, FLOAT, 2.5, "Sqrt exact", func(a, b Float) Evaluable { return a.Sqrt() }},
+	{1, 0, 200, big.ToZero, UINT, 200, "Sqrt prec", func(a, b Float) Evaluable { return NewUint(uint64(a.Sqrt().Prec())) }},
+	{0.5, 0.25, 0, 0, FLOAT, 0.75, "Add", func(a, b Float) Evaluable { return a.Add(b) }},
+	{0.5, 0.75, 0, 0, FLOAT, -0.25, "Sub", func(a, b Float) Evaluable { return a.Sub(b) }},
+	{1.5, -2, 0, 0, FLOAT, -3, "Mul", func(a, b Float) Evaluable { return a.Mul(b) }},
+	{1, 4, 0, 0, FLOAT, 0.25, "Quo", func(a, b Float) Evaluable { return a.Quo(b) }},
+	{1, 3, 100, 0, UINT, 100, "Quo prec", func(a, b Float) Evaluable { return NewUint(uint64(a.Quo(b).Prec())) }},
+	{1, 2, 0, 0, INTEGER, -1, "Cmp", func(a, b Float) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{1.5, 0, 0, 0, FLOAT, -1.5, "Neg", func(a, b Float) Evaluable { return a.Neg() }},
+	{-1.5, 0, 0, 0, FLOAT, 1.5, "Abs", func(a, b Float) Evaluable { return a.Abs() }},
+	{2.9, 0, 2, big.ToZero, FLOAT, 2, "mode ToZero", func(a, b Float) Evaluable { return a }},
+	{2.1, 0, 2, big.AwayFromZero, FLOAT, 3, "mode AwayFromZero", func(a, b Float) Evaluable { return a }},
+	{2.75, 0, 0, 0, FLOAT, 3, "SetPrec", func(a, b Float) Evaluable { return a.SetPrec(2) }},
+	{0.75, 0, 0, 0, RATIONAL, 0.75, "Ratio", func(a, b Float) Evaluable { r, _ := a.Ratio(); return r }},
+	{0.1, 0, 0, 0, RATIONAL, 0.1, "Ratio exact", func(a, b Float) Evaluable { r, _ := a.Ratio(); return r }},
+	{0.75, 0, 0, 0, FLOAT, 0.75, "from Ratio", func(a, b Float) Evaluable { r, _ := a.Ratio(); return r.Float(10) }},
+	{0.625, 0, 0, 0, RATIONAL, 0.625, "round trip", func(a, b Float) Evaluable {
+		r, _ := a.Ratio()
+		r, _ = r.Float(DefaultPrec).Ratio()
+		return r
+	}},
+	{0.5, 0, 0, 0, FLOAT, 0.5, "Float64", func(a, b Float) Evaluable { x, _ := a.Float64(); return NewFloat(x) }},
+	{0.5, 0, 0, 0, FLOAT, 2.5, "Add Integer", func(a, b Float) Evaluable { r, _ := Add(a, NewInteger(2)); return r }},
+	{1, 1, 200, 0, UINT, 200, "Add prec", func(a, b Float) Evaluable {
+		r, _ := Add(NewInteger(1), a)
+		return NewUint(uint64(r.(Float).Prec()))
+	}},
+	{0.1, 0, 0, 0, INTEGER, 1, "Cmp exact", func(a, b Float) Evaluable { r, _ := Cmp(a, ratioOf(1, 10)); return r }},
+	{math.Inf(-1), 0, 0, 0, INTEGER, -1, "Cmp inf", func(a, b Float) Evaluable { r, _ := Cmp(a, NewInteger(0)); return r }},
+	{0.5, 0, 0, 0, RATIONAL, 1, "Collect", func(a, b Float) Evaluable { return Collect(Value(1), a).(ArrayList).Values()[0] }},
+}
+
+func TestFloat(t *testing.T) {
+	for n, test := range floatTests {
+		var a = floatOperand(test.a, test.prec, test.mode)
+		testValue(t, n, test.opStr, test.op(a, NewFloat(test.b)), test.typ, floatExpected(test.typ, test.exp))
+	}
+}
+
+// floats format like big.Float, results of the operation get formatted by
+// the format string
+var floatFormatTests = []struct {
+	a      float64
+	prec   uint
+	format string
+	exp    string
+	opStr  string
+	op     func(a Float) Float
+}{
+	{1.25, 0, "%v", "1.25", "%v", func(a Float) Float { return a }},
+	{1234.5, 0, "%.3e", "1.234e+03", "%e", func(a Float) Float { return a }},
+	{3.14159, 0, "%.2f", "3.14", "%f", func(a Float) Float { return a }},
+	{0.0000152587890625, 0, "%g", "1.52587890625e-05", "%g", func(a Float) Float { return a }},
+	{-2.25, 0, "%10.1f", "      -2.2", "%10.1f", func(a Float) Float { return a }},
+	{2, 128, "%.30f", "1.414213562373095048801688724210", "Sqrt", func(a Float) Float { return a.Sqrt() }},
+	{1, 64, "%.10g", "0.3333333333", "Quo", func(a Float) Float { return a.Quo(NewFloat(3)) }},
+}
+
+func TestFloatFormat(t *testing.T) {
+	for n, test := range floatFormatTests {
+		var a = floatOperand(test.a, test.prec, big.ToNearestEven)
+		if got := fmt.Sprintf(test.format, test.op(a)); got != test.exp {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %q expected: %q", n, test.opStr, got, test.exp))
+		}
+	}
+}
+
+// predicates, accuracy and errors of float operations
+var floatPredicateTests = []struct {
+	a, b  float64
+	exp   bool
+	opStr string
+	op    func(a, b Float) bool
+}{
+	{1.25, 0, true, "String", func(a, b Float) bool { return a.String() == "1.25" }},
+	{0.5, 0, true, "Float64 exact", func(a, b Float) bool { _, exact := a.Float64(); return exact }},
+	{math.Inf(1), 0, false, "Ratio inf", func(a, b Float) bool { _, ok := a.Ratio(); return ok }},
+	{0, 0, true, "SetMode", func(a, b Float) bool { return a.SetMode(big.ToZero).Mode() == big.ToZero }},
+	{0, 0, true, "from Ratio Acc", func(a, b Float) bool {
+		return ratioOf(1, 3).(Ratio).Float(DefaultPrec).Acc() == big.Above
+	}},
+	{0, 0, true, "parse error", func(a, b Float) bool { _, err := ParseFloat("x", 64, big.ToNearestEven); return err != nil }},
+	{math.Inf(1), math.Inf(1), true, "NaN", func(a, b Float) bool { _, err := Sub(a, b); return err != nil }},
+	{1, 0, true, "Quo zero", func(a, b Float) bool { _, err := Quo(a, b); return err != nil }},
+}
+
+func TestFloatPredicates(t *testing.T) {
+	for n, test := range floatPredicateTests {
+		testValue(t, n, test.opStr, Value(test.op(NewFloat(test.a), NewFloat(test.b))), BOOL, Value(test.exp))
+	}
+}
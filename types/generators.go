@@ -46,14 +46,14 @@ involve two steps, to first generate the appropriate second level type, that
 comes with the nesccessary features to implement- and therefore comes with the
 methods to convert to the wanted third level type.
 
-         ┌──────┬────────────┐
-   Big	 │ Rat	│ Lst,Map,Set│
-   ──────┼──────┼────────────┤
-   Simple│ Tuple│ Collection │
-  	 └──────┴────┬───────┘
-                     │
-		     ▼
-  		   paired
+	         ┌──────┬────────────┐
+	   Big	 │ Rat	│ Lst,Map,Set│
+	   ──────┼──────┼────────────┤
+	   Simple│ Tuple│ Collection │
+	  	 └──────┴────┬───────┘
+	                     │
+			     ▼
+	  		   paired
 */
 package types
 
@@ -66,9 +66,9 @@ import (
 // INSTANCIATE NEW VALUE(S) FROM GOLANG NATIVE VALUES
 //
 // 1.) chack number of passed values:
-//	- one: pass on to convert from native type
-//	- two: pass on to create a pair of values
-//	- > two:  pass on to create a collection
+//   - one: pass on to convert from native type
+//   - two: pass on to create a pair of values
+//   - > two:  pass on to create a collection
 func Value(i ...interface{}) (v Evaluable) {
 
 	// IF SINGLE ELEMENT GOT PASSED
@@ -90,7 +90,7 @@ func Value(i ...interface{}) (v Evaluable) {
 	// if exactly two elements, assume a pair of key/value as element for a map
 	if len(i) == 2 { // convert key and value recursively to make shure
 		// they implement evaluate
-		v = pairFromValues(Value(i[0]), Value(i[1]))
+		v = pairFromValues(pairElement(i[0]), pairElement(i[1]))
 	}
 
 	// MORE THAN TWO ELEMENTS GOT PASSED
@@ -112,9 +112,9 @@ func nativeToValue(i interface{}) (r Evaluable) {
 		} else {
 			r = wrap(intPool.Get().(*big.Int).SetInt64(0)).(val).Bool()
 		}
-	case uint, uint8, uint16, uint32, uint64, ValueType: // a uint is assumed to be a single byte
+	case uint, uint8, uint16, uint32, uint64, uintptr: // unsigned
 		r = divideUints(i)
-	case int, int16, int32, int64: // integers are integer
+	case int, int8, int16, int32, int64: // integers are integer
 		r = divideInts(i)
 	case float32: // floating point values get assigned to rationals
 		r = wrap(ratPool.Get().(*big.Rat).SetFloat64(float64(i.(float32))))
@@ -122,28 +122,34 @@ func nativeToValue(i interface{}) (r Evaluable) {
 		r = wrap(ratPool.Get().(*big.Rat).SetFloat64(i.(float64)))
 	case []byte: // == uint8
 		r = wrap(intPool.Get().(*big.Int).SetBytes(i.([]byte)))
-	case string: // a string gets assigned by its bislice as well
-		val, ok := intPool.Get().(*big.Int).SetString(i.(string), 10)
-		if ok {
+	case string: // numeric strings become integers, all others text
+		var val = intPool.Get().(*big.Int)
+		if _, ok := val.SetString(i.(string), 10); ok {
 			r = wrap(val)
+		} else {
+			discardInt(val)
+			r = NewText(i.(string))
 		}
 	}
 	return r
 }
+
+// all unsigned native types become Uint. Value types are evaluable and never
+// get here.
 func divideUints(i interface{}) (r Evaluable) {
 	switch i.(type) {
-	case ValueType:
-		r = wrap(intPool.Get().(*big.Int).SetUint64(uint64(i.(ValueType))))
-	case byte:
-		r = wrap(intPool.Get().(*big.Int).SetBytes([]byte(i.([]byte))))
-	case rune:
-		r = wrap(intPool.Get().(*big.Int).SetUint64(uint64(i.(uint32))))
-	case uint:
-		r = wrap(intPool.Get().(*big.Int).SetUint64(uint64(i.(uint))))
+	case uint8: // == byte
+		r = NewUint(uint64(i.(uint8)))
 	case uint16:
-		r = wrap(intPool.Get().(*big.Int).SetUint64(uint64(i.(uint16))))
+		r = NewUint(uint64(i.(uint16)))
+	case uint32:
+		r = NewUint(uint64(i.(uint32)))
+	case uint:
+		r = NewUint(uint64(i.(uint)))
+	case uintptr:
+		r = NewUint(uint64(i.(uintptr)))
 	case uint64:
-		r = wrap(intPool.Get().(*big.Int).SetUint64(i.(uint64)))
+		r = NewUint(i.(uint64))
 	}
 	return r
 }
@@ -151,6 +157,8 @@ func divideInts(i interface{}) (r Evaluable) {
 	switch i.(type) {
 	case int:
 		r = wrap(intPool.Get().(*big.Int).SetInt64(int64(i.(int))))
+	case int8:
+		r = wrap(intPool.Get().(*big.Int).SetInt64(int64(i.(int8))))
 	case int16:
 		r = wrap(intPool.Get().(*big.Int).SetInt64(int64(i.(int16))))
 	case int32:
@@ -162,7 +170,198 @@ func divideInts(i interface{}) (r Evaluable) {
 }
 
 // collection generator determines which type of collection to allocate, based
-// on the parameters it gets passed
+// on the parameters it gets passed. As described above, all values get
+// collected in an array list first, which is analyzed to determine the
+// appropriate second level type:
+//
+//   - scalars are returned as array list, unified to a common type if need be
+//   - pairs with at least one symbolic key, are mapped by a hash map
+//   - pairs with numeric keys only, are returned as list of pairs ordered
+//     by index
+//
+// Values that are not paired, get paired with their position in the list as
+// key, when mixed with pairs.
 func Collect(v ...Evaluable) (r Collected) {
+	// 1.) collect all passed values in an array list
+	var l = newOrderedList(v...)
+
+	// 2.) determine which types of values, or keys are contained
+	var types, keys ValueType
+	var pairs int
+	for _, v := range l.Values() {
+		if p, ok := v.(Pair); ok {
+			pairs = pairs + 1
+			keys = keys | scalarType(p.Key())
+			continue
+		}
+		types = types | scalarType(v)
+	}
+
+	// 3.) convert to the appropriate type
+	switch {
+	case pairs == 0: // flat list of scalars
+		r = unifyList(l, types)
+	case keys&SYMBOLIC != 0: // at least one symbolic key
+		r = newMap().Add(l.Values()...)
+	default: // numeric keys only
+		r = indexedList(l)
+	}
+	return r
+}
+
+// scalarType returns the type of the value, plain values returned by
+// nativeToValue are considered to be integers
+func scalarType(v Evaluable) ValueType {
+	if v == nil {
+		return EMPTY
+	}
+	if _, ok := v.(val); ok {
+		return INTEGER
+	}
+	return v.Type()
+}
+
+// unifyList converts all values to a common type, if the list contains more
+// than one type. Numeric values get promoted to the highest ranking type
+// contained (bool → integer → decimal → rational), which can represent all
+// others without loosing information. Floats are binary fractions, they get
+// converted to ratios by their exact value, instead of rounding all other
+// values to floats. Lists containing infinite floats are kept, since those
+// have no exact value. Text and bytes mixed, are
+// unified to bytes, which can represent any text, but not vice versa. Lists
+// mixing numeric with symbolic values, or containing collections are kept as
+// they are, since no common type exists to represent them all.
+func unifyList(l ArrayList, types ValueType) ArrayList {
+	var to ValueType
+	switch {
+	case types == INTEGER: // plain values get typed
+		to = INTEGER
+	case types&(types-1) == 0: // single type, or empty list
+		return l
+	case types&^(BOOL|UINT|INTEGER|DECIMAL|RATIONAL|FLOAT) == 0:
+		switch {
+		case types&FLOAT != 0:
+			for _, v := range l.Values() {
+				if f, ok := v.(Float); ok && f().IsInf() {
+					return l
+				}
+			}
+			to = RATIONAL
+		case types&RATIONAL != 0:
+			to = RATIONAL
+		case types&DECIMAL != 0:
+			to = DECIMAL
+		default:
+			to = INTEGER
+		}
+	case types&^SYMBOLIC == 0:
+		to = BYTES
+	default:
+		return l
+	}
+	var r = newArrayList()
+	for _, v := range l.Values() {
+		r().Add(promote(v, to))
+	}
 	return r
 }
+
+// indexedList returns a list of pairs, ordered by the numeric value of their
+// keys. Unpaired values are paired with their position as key.
+func indexedList(l ArrayList) ArrayList {
+	var r = newArrayList()
+	for i, v := range l.Values() {
+		if _, ok := v.(Pair); !ok {
+			v = pairFromValues(Value(i), v)
+		}
+		r().Add(v)
+	}
+	r.Sort(func(a, b Evaluable) int {
+		x, ok := bigRatOf(a.(Pair).Key())
+		if !ok {
+			x = new(big.Rat)
+		}
+		y, ok := bigRatOf(b.(Pair).Key())
+		if !ok {
+			y = new(big.Rat)
+		}
+		return x.Cmp(y)
+	})
+	return r
+}
+
+// promote converts the value to the passed type, if it can be represented by
+// that type without loss. Otherwise the value is returned unchanged.
+func promote(v Evaluable, to ValueType) Evaluable {
+	switch to {
+	case INTEGER:
+		if i, ok := bigIntOf(v); ok {
+			return wrap(intPool.Get().(*big.Int).Set(i)).(val).Integer()
+		}
+	case RATIONAL:
+		if r, ok := bigRatOf(v); ok {
+			return wrap(ratPool.Get().(*big.Rat).Set(r)).(Ratio)
+		}
+	case DECIMAL:
+		if d, ok := v.(Decimal); ok {
+			return d
+		}
+		if i, ok := bigIntOf(v); ok {
+			return decimal(i, 0)
+		}
+	case FLOAT:
+		if f, ok := v.(Float); ok {
+			return f
+		}
+		if r, ok := bigRatOf(v); ok {
+			if f := newFloat(DefaultPrec, big.ToNearestEven).SetRat(r); f.Acc() == big.Exact {
+				return wrap(f).(Float)
+			}
+		}
+	case BYTES:
+		switch x := v.(type) {
+		case Text:
+			return NewBytes(x.Serialize())
+		case Bytes:
+			return x
+		}
+	}
+	return v
+}
+
+// bigIntOf returns the big Int enclosed by integral values
+func bigIntOf(v Evaluable) (*big.Int, bool) {
+	switch x := v.(type) {
+	case val:
+		return x(), true
+	case Bool:
+		return x(), true
+	case Integer:
+		return x(), true
+	case Uint:
+		return x(), true
+	case BitFlag:
+		return x(), true
+	}
+	return nil, false
+}
+
+// bigRatOf returns the value of numeric scalars as big Rat
+func bigRatOf(v Evaluable) (*big.Rat, bool) {
+	switch x := v.(type) {
+	case Ratio:
+		return x(), true
+	case Decimal:
+		return x.rat(), true
+	case Float:
+		if x().IsInf() {
+			return nil, false
+		}
+		var r, _ = x().Rat(nil)
+		return r, true
+	}
+	if i, ok := bigIntOf(v); ok {
+		return new(big.Rat).SetInt(i), true
+	}
+	return nil, false
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+var collectTests = []struct {
+	in  func() []Evaluable // evaluated after the pools got initialized
+	typ ValueType
+	ex  string
+}{
+	{ // homogeneous scalars stay a list of integers
+		func() []Evaluable { return []Evaluable{Value(1), Value(2), Value(3)} },
+		LIST, "INTEGER:1 INTEGER:2 INTEGER:3",
+	},
+	{ // bools get promoted to integers
+		func() []Evaluable { return []Evaluable{Value(true), Value(2), Value(3)} },
+		LIST, "INTEGER:1 INTEGER:2 INTEGER:3",
+	},
+	{ // integers get promoted to ratios
+		func() []Evaluable { return []Evaluable{Value(1), Value(0.5), Value(2)} },
+		LIST, "RATIONAL:1/1 RATIONAL:1/2 RATIONAL:2/1",
+	},
+	{ // floats mixed with exact numbers get converted to ratios by their exact value
+		func() []Evaluable { return []Evaluable{ratioOf(1, 3), NewFloat(0.5), Value(1)} },
+		LIST, "RATIONAL:1/3 RATIONAL:1/2 RATIONAL:1/1",
+	},
+	{ // integers beyond 64 bit are kept exactly
+		func() []Evaluable {
+			var i, _ = ParseInteger("1606938044258990275541962092341162602522202993782792835301377")
+			return []Evaluable{i, NewFloat(0.25)}
+		},
+		LIST, "RATIONAL:1606938044258990275541962092341162602522202993782792835301377/1 RATIONAL:1/4",
+	},
+	{ // infinite floats have no exact value, the list is kept
+		func() []Evaluable { return []Evaluable{Value(1), NewFloat(math.Inf(1))} },
+		LIST, "INTEGER:1 FLOAT:+Inf",
+	},
+	{ // text mixed with bytes becomes bytes
+		func() []Evaluable { return []Evaluable{NewBytes([]byte("ab")), Value([]byte("cd")).(val).Text()} },
+		LIST, "BYTES:ab BYTES:cd",
+	},
+	{ // numbers mixed with text are kept
+		func() []Evaluable { return []Evaluable{Value(1), Value([]byte("cd")).(val).Text()} },
+		LIST, "INTEGER:1 TEXT:cd",
+	},
+	{ // pairs with symbolic keys get mapped
+		func() []Evaluable {
+			return []Evaluable{Value(NewBytes([]byte("b")), 2), Value(NewBytes([]byte("a")), 1)}
+		},
+		MAP, "BYTES:a=INTEGER:1 BYTES:b=INTEGER:2",
+	},
+	{ // unpaired values get mapped on to their position
+		func() []Evaluable { return []Evaluable{Value(NewBytes([]byte("a")), 1), Value(2)} },
+		MAP, "BYTES:a=INTEGER:1 INTEGER:1=INTEGER:2",
+	},
+	{ // pairs with numeric keys are listed by index
+		func() []Evaluable { return []Evaluable{Value(2, 20), Value(0.5, 5), Value(1, 10)} },
+		LIST, "RATIONAL:1/2=INTEGER:5 INTEGER:1=INTEGER:10 INTEGER:2=INTEGER:20",
+	},
+}
+
+// serializes elements as type:value, pairs as key=value, maps sorted by key
+func collectString(c Collected) string {
+	var r = []string{}
+	switch c := c.(type) {
+	case Mapped:
+		var keys = c.Keys()
+		var values = c.Values()
+		for i := range keys {
+			r = append(r, valueKey(keys[i])+"="+valueKey(values[i]))
+		}
+		for i := range r { // insertion sort, maps are unordered
+			for j := i; j > 0 && r[j] < r[j-1]; j-- {
+				r[j], r[j-1] = r[j-1], r[j]
+			}
+		}
+	default:
+		for _, v := range c.Values() {
+			if p, ok := v.(Pair); ok {
+				r = append(r, valueKey(p.Key())+"="+valueKey(p.Value()))
+			} else {
+				r = append(r, valueKey(v))
+			}
+		}
+	}
+	return strings.Join(r, " ")
+}
+
+func TestCollect(t *testing.T) {
+	for n, test := range collectTests {
+		c := Collect(test.in()...)
+		if c.Type() != test.typ || collectString(c) != test.ex {
+			(*t).Fail()
+			(*t).Log("failed collect test: " + fmt.Sprint(n) +
+				" got: " + c.Type().String() + " " + collectString(c) +
+				" expected: " + test.typ.String() + " " + test.ex)
+		}
+	}
+}
+
+// values of more than two elements get collected
+func TestValueCollect(t *testing.T) {
+	c, ok := Value(1, 2, 3).(Collected)
+	if !ok || c.Size() != 3 {
+		(*t).Fail()
+		(*t).Log("failed: Value(1, 2, 3) got: " + fmt.Sprint(c))
+	}
+	m := Collect(Value(NewBytes([]byte("k")), 1)).(Mapped)
+	if v, ok := m.Put(NewBytes([]byte("l")), Value(2)).(HashMap).Get(NewBytes([]byte("l"))); !ok || v.String() != "2" {
+		(*t).Fail()
+		(*t).Log("failed: map lookup got: " + fmt.Sprint(v))
+	}
+	if !Collect(Value(1), Value(2)).(ArrayList).Contains(Value(2)) {
+		(*t).Fail()
+		(*t).Log("failed: list does not contain value")
+	}
+}
@@ -0,0 +1,323 @@
+package types
+
+//////////////////////////////////////////////////////////////////////////
+//// PERSISTENT HASH MAP & SET
+///
+// hash trie maps and sets are immutable, implemented as hash array mapped
+// trie. Each level of the trie consumes five bits of the keys hash, nodes
+// only allocate entries for the bits that are in use, which are marked by a
+// bitmap. Keys that share their hash are kept in a list of collisions and told
+// apart by Equal. Updates copy the path from the root to the changed entry
+// and share all other nodes with the previous version, so that putting and
+// removing take O(log n) time.
+type (
+	HashTrieMap func() *hashTrie
+	HashTrieSet func() *hashTrie
+)
+
+type hashTrie struct {
+	size int
+	root *hamtNode
+}
+
+type hamtNode struct {
+	bitmap  uint32
+	entries []hamtEntry
+}
+
+// an entry either references a sub node, or holds all pairs of one hash
+type hamtEntry struct {
+	hash  uint64
+	pairs []Pair
+	node  *hamtNode
+}
+
+//// MAP
+func (m HashTrieMap) Eval() Evaluable           { return evalCollection(m) }
+func (m HashTrieMap) Type() ValueType           { return MAP }
+func (m HashTrieMap) Size() int                 { return m().size }
+func (m HashTrieMap) Empty() bool               { return m().size == 0 }
+func (m HashTrieMap) Clear() Collected          { return NewHashTrieMap() }
+func (m HashTrieMap) Serialize() []byte         { return serializeMap(m) }
+func (m HashTrieMap) String() string            { return mapToString(m) }
+func (m HashTrieMap) Interfaces() []interface{} { return interfaceSlice(m.Values()) }
+
+// Add returns a version with the passed pairs mapped by their key, all other
+// values are mapped by their position.
+func (m HashTrieMap) Add(v ...Evaluable) Mapped {
+	var r = m
+	for i, v := range v {
+		if p, ok := v.(Pair); ok {
+			r = r.Put(p.Key(), p.Value()).(HashTrieMap)
+			continue
+		}
+		r = r.Put(Value(i), v).(HashTrieMap)
+	}
+	return r
+}
+
+// Put returns a version with the value mapped on to the key
+func (m HashTrieMap) Put(k, v Evaluable) Mapped {
+	var t = *m()
+	var added bool
+	t.root, added = t.root.put(0, Hash(k), pairFromValues(k, v))
+	if added {
+		t.size++
+	}
+	return hashTrieMapOf(&t)
+}
+func (m HashTrieMap) Get(k Evaluable) (Evaluable, bool) {
+	if p, ok := m().root.get(0, Hash(k), k); ok {
+		return p.Value(), true
+	}
+	return nil, false
+}
+
+// Remove returns a version without the key
+func (m HashTrieMap) Remove(k Evaluable) Mapped {
+	var t = *m()
+	var removed bool
+	t.root, removed = t.root.remove(0, Hash(k), k)
+	if !removed {
+		return m
+	}
+	t.size--
+	return hashTrieMapOf(&t)
+}
+
+// keys and values are returned in canonical order of the keys
+func (m HashTrieMap) Keys() []Evaluable {
+	var r = []Evaluable{}
+	for _, p := range m.pairs() {
+		r = append(r, p.(Pair).Key())
+	}
+	return r
+}
+func (m HashTrieMap) Values() []Evaluable {
+	var r = []Evaluable{}
+	for _, p := range m.pairs() {
+		r = append(r, p.(Pair).Value())
+	}
+	return r
+}
+func (m HashTrieMap) pairs() []Evaluable { return sortValues(m().root.collect([]Evaluable{}), byKey) }
+
+// NewHashTrieMap allocates a map of the passed values, see Add
+func NewHashTrieMap(v ...Evaluable) HashTrieMap {
+	return hashTrieMapOf(&hashTrie{}).Add(v...).(HashTrieMap)
+}
+func hashTrieMapOf(t *hashTrie) HashTrieMap { return func() *hashTrie { return t } }
+
+//// SET
+// sets map each element on to itself
+func (s HashTrieSet) Eval() Evaluable           { return evalCollection(s) }
+func (s HashTrieSet) Type() ValueType           { return SET }
+func (s HashTrieSet) Size() int                 { return s().size }
+func (s HashTrieSet) Empty() bool               { return s().size == 0 }
+func (s HashTrieSet) Clear() Collected          { return NewHashTrieSet() }
+func (s HashTrieSet) Serialize() []byte         { return serializeSet(s) }
+func (s HashTrieSet) String() string            { return string(serializeSet(s)) }
+func (s HashTrieSet) Interfaces() []interface{} { return interfacesFromSet(s) }
+
+// elements in canonical order
+func (s HashTrieSet) Values() []Evaluable {
+	var r = []Evaluable{}
+	for _, p := range sortValues(s().root.collect([]Evaluable{}), byKey) {
+		r = append(r, p.(Pair).Key())
+	}
+	return r
+}
+
+// Add returns a version containing the passed values
+func (s HashTrieSet) Add(v ...Evaluable) DeDublicated {
+	var t = *s()
+	for _, v := range v {
+		var added bool
+		t.root, added = t.root.put(0, Hash(v), pairFromValues(v, v))
+		if added {
+			t.size++
+		}
+	}
+	return hashTrieSetOf(&t)
+}
+
+// Remove returns a version without the passed values
+func (s HashTrieSet) Remove(v ...Evaluable) DeDublicated {
+	var t = *s()
+	for _, v := range v {
+		var removed bool
+		t.root, removed = t.root.remove(0, Hash(v), v)
+		if removed {
+			t.size--
+		}
+	}
+	return hashTrieSetOf(&t)
+}
+func (s HashTrieSet) Contains(v ...Evaluable) bool {
+	for _, v := range v {
+		if _, ok := s().root.get(0, Hash(v), v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// NewHashTrieSet allocates a set containing the passed values
+func NewHashTrieSet(v ...Evaluable) HashTrieSet {
+	return hashTrieSetOf(&hashTrie{}).Add(v...).(HashTrieSet)
+}
+func hashTrieSetOf(t *hashTrie) HashTrieSet { return func() *hashTrie { return t } }
+
+//// NODES
+// position of the hash bits at the level and the index of the entry, the
+// bit refers to.
+func (n *hamtNode) locate(shift uint, h uint64) (bit uint32, pos int) {
+	bit = 1 << ((h >> shift) & trieMask)
+	return bit, popCount(n.bitmap & (bit - 1))
+}
+
+// puts the pair, copying the path to its entry. Returns true, if the key got
+// added, false if it replaced the value of an equal key.
+func (n *hamtNode) put(shift uint, h uint64, p Pair) (*hamtNode, bool) {
+	if n == nil {
+		n = &hamtNode{}
+	}
+	var bit, pos = n.locate(shift, h)
+	var r = &hamtNode{bitmap: n.bitmap}
+	if n.bitmap&bit == 0 {
+		r.bitmap |= bit
+		r.entries = make([]hamtEntry, 0, len(n.entries)+1)
+		r.entries = append(r.entries, n.entries[:pos]...)
+		r.entries = append(r.entries, hamtEntry{hash: h, pairs: []Pair{p}})
+		r.entries = append(r.entries, n.entries[pos:]...)
+		return r, true
+	}
+	r.entries = append([]hamtEntry{}, n.entries...)
+	var e = n.entries[pos]
+	var added = true
+	switch {
+	case e.node != nil:
+		r.entries[pos].node, added = e.node.put(shift+trieBits, h, p)
+	case e.hash == h: // replace an equal key, or add a collision
+		var pairs = append([]Pair{}, e.pairs...)
+		for i, x := range pairs {
+			if Equal(x.Key(), p.Key()) {
+				pairs[i] = p
+				added = false
+			}
+		}
+		if added {
+			pairs = append(pairs, p)
+		}
+		r.entries[pos] = hamtEntry{hash: h, pairs: pairs}
+	default: // hashes differ, push both down one level
+		var sub = &hamtNode{entries: []hamtEntry{e}}
+		sub.bitmap, _ = sub.locate(shift+trieBits, e.hash)
+		sub, _ = sub.put(shift+trieBits, h, p)
+		r.entries[pos] = hamtEntry{node: sub}
+	}
+	return r, added
+}
+
+// pair of the equal key
+func (n *hamtNode) get(shift uint, h uint64, k Evaluable) (Pair, bool) {
+	for n != nil {
+		var bit, pos = n.locate(shift, h)
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+		var e = n.entries[pos]
+		if e.node == nil {
+			for _, p := range e.pairs {
+				if e.hash == h && Equal(p.Key(), k) {
+					return p, true
+				}
+			}
+			return nil, false
+		}
+		n, shift = e.node, shift+trieBits
+	}
+	return nil, false
+}
+
+// removes the equal key, copying the path to its entry. Nodes left empty get
+// removed, sub nodes left with a single list of pairs are replaced by it.
+func (n *hamtNode) remove(shift uint, h uint64, k Evaluable) (*hamtNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	var bit, pos = n.locate(shift, h)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	var e = n.entries[pos]
+	switch {
+	case e.node != nil:
+		var sub, removed = e.node.remove(shift+trieBits, h, k)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case sub == nil:
+			return n.without(bit, pos), true
+		case len(sub.entries) == 1 && sub.entries[0].node == nil:
+			e = sub.entries[0]
+		default:
+			e = hamtEntry{node: sub}
+		}
+	case e.hash == h:
+		var pairs = []Pair{}
+		for _, p := range e.pairs {
+			if !Equal(p.Key(), k) {
+				pairs = append(pairs, p)
+			}
+		}
+		if len(pairs) == len(e.pairs) {
+			return n, false
+		}
+		if len(pairs) == 0 {
+			return n.without(bit, pos), true
+		}
+		e = hamtEntry{hash: h, pairs: pairs}
+	default:
+		return n, false
+	}
+	var r = &hamtNode{bitmap: n.bitmap, entries: append([]hamtEntry{}, n.entries...)}
+	r.entries[pos] = e
+	return r, true
+}
+
+// copy of the node without the entry, nil if none are left
+func (n *hamtNode) without(bit uint32, pos int) *hamtNode {
+	if len(n.entries) == 1 {
+		return nil
+	}
+	var r = &hamtNode{bitmap: n.bitmap &^ bit}
+	r.entries = append(append([]hamtEntry{}, n.entries[:pos]...), n.entries[pos+1:]...)
+	return r
+}
+
+// appends all pairs
+func (n *hamtNode) collect(r []Evaluable) []Evaluable {
+	if n == nil {
+		return r
+	}
+	for _, e := range n.entries {
+		if e.node != nil {
+			r = e.node.collect(r)
+			continue
+		}
+		for _, p := range e.pairs {
+			r = append(r, p)
+		}
+	}
+	return r
+}
+
+// number of set bits
+func popCount(x uint32) (n int) {
+	for ; x != 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,185 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+var (
+	_ Mapped       = HashTrieMap(nil)
+	_ DeDublicated = HashTrieSet(nil)
+)
+
+// map of the integers from zero to n-1 on to their text representation
+func intTrieMap(n int) HashTrieMap {
+	var m = NewHashTrieMap()
+	for i := 0; i < n; i++ {
+		m = m.Put(NewInteger(int64(i)), NewText(fmt.Sprint(i))).(HashTrieMap)
+	}
+	return m
+}
+
+// determines if the map holds the first n integers mapped on to their text
+func mapsInts(m HashTrieMap, n int) bool {
+	if m.Size() != n {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if v, ok := m.Get(NewInteger(int64(i))); !ok || v.String() != fmt.Sprint(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// function type, to be passed in the test slug
+type hamtTestFunc func(m HashTrieMap) Evaluable
+
+// the operand maps the integers from zero to n-1 on to their text. Results of
+// more than one value are returned as list.
+var hamtTests = []struct {
+	n     int
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    hamtTestFunc
+}{
+	{0, LIST, []interface{}{2, true, 2}, "Put Get", func(m HashTrieMap) Evaluable {
+		var r = m.Put(NewText("a"), NewInteger(1)).Put(NewText("b"), NewInteger(2))
+		v, ok := r.(HashTrieMap).Get(NewText("b"))
+		return NewArrayList(v, Value(ok), NewInteger(int64(r.Size())))
+	}},
+	{0, BOOL, false, "Get missing", func(m HashTrieMap) Evaluable { _, ok := m.Get(NewText("a")); return Value(ok) }},
+	{0, LIST, []interface{}{1, "b"}, "Put replaces", func(m HashTrieMap) Evaluable {
+		m = m.Put(Value(1), NewText("a")).Put(NewInteger(1), NewText("b")).(HashTrieMap)
+		v, _ := m.Get(NewInteger(1))
+		return NewArrayList(NewInteger(int64(m.Size())), v)
+	}},
+	{0, LIST, []interface{}{1, 2}, "Put persistent", func(m HashTrieMap) Evaluable {
+		var a = m.Put(NewText("a"), NewInteger(1)).(HashTrieMap)
+		var b = a.Put(NewText("a"), NewInteger(2)).(HashTrieMap)
+		x, _ := a.Get(NewText("a"))
+		y, _ := b.Get(NewText("a"))
+		return NewArrayList(x, y)
+	}},
+	{3000, BOOL, true, "many", func(m HashTrieMap) Evaluable { return Value(mapsInts(m, 3000)) }},
+	{3000, LIST, []interface{}{1500, false, "3", true}, "Remove", func(m HashTrieMap) Evaluable {
+		var b = m
+		for i := 0; i < 3000; i += 2 {
+			b = b.Remove(NewInteger(int64(i))).(HashTrieMap)
+		}
+		_, ok := b.Get(NewInteger(2))
+		v, _ := b.Get(NewInteger(3))
+		return NewArrayList(NewInteger(int64(b.Size())), Value(ok), v, Value(mapsInts(m, 3000)))
+	}},
+	{500, LIST, []interface{}{0, true}, "Remove all", func(m HashTrieMap) Evaluable {
+		for i := 0; i < 500; i++ {
+			m = m.Remove(NewInteger(int64(i))).(HashTrieMap)
+		}
+		return NewArrayList(NewInteger(int64(m.Size())), Value(m().root == nil))
+	}},
+	{3, INTEGER, 3, "Remove missing", func(m HashTrieMap) Evaluable { return NewInteger(int64(m.Remove(NewInteger(5)).Size())) }},
+	{0, LIST, []interface{}{2, "a", "b"}, "Keys", func(m HashTrieMap) Evaluable {
+		return NewArrayList(NewHashTrieMap(Value("b", 2), Value("a", 1), NewText("x")).Keys()...)
+	}},
+	{0, LIST, []interface{}{1, 2, 3, 3, false, 1, true}, "collisions", func(m HashTrieMap) Evaluable {
+		var n, _ = (*hamtNode)(nil).put(0, 7, pairFromValues(NewText("a"), NewInteger(1)))
+		n, _ = n.put(0, 7, pairFromValues(NewText("b"), NewInteger(2)))
+		n, _ = n.put(0, 7|1<<62, pairFromValues(NewText("c"), NewInteger(3)))
+		a, _ := n.get(0, 7, NewText("a"))
+		b, _ := n.get(0, 7, NewText("b"))
+		c, _ := n.get(0, 7|1<<62, NewText("c"))
+		var size = len(n.collect(nil))
+		n, _ = n.remove(0, 7, NewText("a"))
+		n, _ = n.remove(0, 7|1<<62, NewText("c"))
+		_, ok := n.get(0, 7, NewText("a"))
+		return NewArrayList(a.Value(), b.Value(), c.Value(), NewInteger(int64(size)),
+			Value(ok), NewInteger(int64(len(n.collect(nil)))), Value(n.entries[0].node == nil))
+	}},
+	{0, LIST, []interface{}{2, []interface{}{1, "b"}, true, false}, "Set", func(m HashTrieMap) Evaluable {
+		var s = NewHashTrieSet(NewText("b"), NewInteger(1), NewText("b"))
+		return NewArrayList(NewInteger(int64(s.Size())), NewArrayList(s.Values()...),
+			Value(s.Contains(NewText("b"))), Value(s.Contains(NewText("c"))))
+	}},
+	{0, LIST, []interface{}{2, 1, false}, "Set Remove", func(m HashTrieMap) Evaluable {
+		var a = NewHashTrieSet(NewText("a"), NewText("b"))
+		var b = a.Remove(NewText("a"), NewText("z"))
+		return NewArrayList(NewInteger(int64(a.Size())), NewInteger(int64(b.Size())), Value(b.Contains(NewText("a"))))
+	}},
+	{0, BOOL, true, "Equal HashMap", func(m HashTrieMap) Evaluable {
+		return Value(Equal(NewHashTrieMap(Value("a", 1)), newHashMap().Put(NewText("a"), NewInteger(1))))
+	}},
+	{0, BOOL, true, "Equal HashSet", func(m HashTrieMap) Evaluable {
+		return Value(Equal(NewHashTrieSet(NewInteger(1), NewInteger(2)), newHashSet(NewInteger(2), NewInteger(1))))
+	}},
+}
+
+func TestHashTrie(t *testing.T) {
+	for n, test := range hamtTests {
+		testValue(t, n, test.opStr, test.op(intTrieMap(test.n)), test.typ, nativeValue(test.exp))
+	}
+}
+
+//// BENCHMARKS
+// persistent maps and sets compared to the hash maps and sets of gods
+func benchmarkKeys(n int) []Evaluable {
+	var r = []Evaluable{}
+	for i := 0; i < n; i++ {
+		r = append(r, NewInteger(int64(i)))
+	}
+	return r
+}
+func BenchmarkHashTrieMapPut(b *testing.B) {
+	var k = benchmarkKeys(10000)
+	var m Mapped = NewHashTrieMap()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m = m.Put(k[i%10000], k[i%10000])
+	}
+}
+func BenchmarkHashMapPut(b *testing.B) {
+	var k = benchmarkKeys(10000)
+	var m Mapped = newHashMap()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m = m.Put(k[i%10000], k[i%10000])
+	}
+}
+func BenchmarkHashTrieMapGet(b *testing.B) {
+	var k = benchmarkKeys(10000)
+	var m = NewHashTrieMap()
+	for _, k := range k {
+		m = m.Put(k, k).(HashTrieMap)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(k[i%10000])
+	}
+}
+func BenchmarkHashMapGet(b *testing.B) {
+	var k = benchmarkKeys(10000)
+	var m = newHashMap()
+	for _, k := range k {
+		m.Put(k, k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(k[i%10000])
+	}
+}
+func BenchmarkHashTrieSetAdd(b *testing.B) {
+	var k = benchmarkKeys(10000)
+	var s DeDublicated = NewHashTrieSet()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s = s.Add(k[i%10000])
+	}
+}
+func BenchmarkHashSetAdd(b *testing.B) {
+	var k = benchmarkKeys(10000)
+	var s DeDublicated = newHashSet()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s = s.Add(k[i%10000])
+	}
+}
@@ -11,7 +11,6 @@ type Integer val
 
 func (i Integer) Eval() Evaluable { return i }
 func (i Integer) Serialize() []byte {
-	defer discardInt(i())
 	return []byte(val(i)().String())
 }
 func (i Integer) String() string  { return val(i).text(10) }
@@ -34,11 +33,11 @@ func (i Integer) Div(y Integer) Integer {
 	defer discardInt(y())
 	return wrap(val(i).div(i(), y())).(val).Integer()
 }
+// DivMod returns euclidean quotient and modulus as pair, operands are left
+// untouched.
 func (i Integer) DivMod(y Integer) Pair {
-	// assume base ten arrithmetic
-	m := Value(10).(val).Integer()
-	defer discardInt(i(), y(), m())
-	return func() [2]Evaluable { return [2]Evaluable{wrap(i), wrap(m)} }
+	d, m := new(big.Int).DivMod(i(), y(), new(big.Int))
+	return pairFromValues(wrap(d).(val).Integer(), wrap(m).(val).Integer())
 }
 func (i Integer) Exp(y Integer) Integer {
 	m := Value(10).(val).Integer()
@@ -80,13 +79,11 @@ func (i Integer) Quo(y Integer) Integer {
 	return wrap(val(i).quo(i(), y())).(val).Integer()
 }
 
+// QuoRem returns truncated quotient and remainder as pair, operands are left
+// untouched.
 func (i Integer) QuoRem(y Integer) Pair {
-	r := intPool.Get().(val).Integer()
-	defer discardInt(i(), y(), r())
-	a, b := val(i).quoRem(i(), y(), r())
-	ret := pairPool.Get().(Pair).SetKey(wrap(a).(val).Integer())
-	ret = ret.SetValue(wrap(b).(val).Integer())
-	return ret
+	a, b := new(big.Int).QuoRem(i(), y(), new(big.Int))
+	return pairFromValues(wrap(a).(val).Integer(), wrap(b).(val).Integer())
 }
 
 func (i Integer) Rand() Integer {
@@ -113,3 +110,19 @@ func (i Integer) SetString(s string, b int) (Integer, bool) {
 	return wrap(x).(val).Integer(), y
 }
 func (i Integer) Uint64() uint64 { return val(i).uint64() }
+
+// NewInteger allocates a fresh Integer instance from a native integer.
+func NewInteger(x int64) Integer {
+	return wrap(intPool.Get().(*big.Int).SetInt64(x)).(val).Integer()
+}
+
+// ParseInteger interprets the passed string as decimal number. If that fails,
+// the second return value is false.
+func ParseInteger(s string) (Integer, bool) {
+	var i = intPool.Get().(*big.Int)
+	if _, ok := i.SetString(s, 10); !ok {
+		discardInt(i)
+		return nil, false
+	}
+	return wrap(i).(val).Integer(), true
+}
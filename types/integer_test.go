@@ -72,3 +72,35 @@ func TestInteger(t *testing.T) {
 
 	}
 }
+
+// quotient and remainder are returned as pair, neither operand changes, nor
+// gets recycled, while still referenced.
+var divisionTests = []struct {
+	a, b  int64
+	exp   string
+	opStr string
+	op    func(a, b Integer) Pair
+}{
+	{7, 2, "3.) 1\n", "DivMod", Integer.DivMod},
+	{-7, 2, "-4.) 1\n", "DivMod", Integer.DivMod},
+	{7, -2, "-3.) 1\n", "DivMod", Integer.DivMod},
+	{7, 2, "3.) 1\n", "QuoRem", Integer.QuoRem},
+	{-7, 2, "-3.) -1\n", "QuoRem", Integer.QuoRem},
+	{7, -2, "-3.) 1\n", "QuoRem", Integer.QuoRem},
+}
+
+func TestIntegerDivision(t *testing.T) {
+	for n, test := range divisionTests {
+		var a, b = NewInteger(test.a), NewInteger(test.b)
+		var got = test.op(a, b).String()
+		for i := 0; i < 10; i++ { // recycled divisors would get overwritten
+			NewInteger(99)
+		}
+		got = got + fmt.Sprint(a.Int64(), b.Int64())
+		if exp := test.exp + fmt.Sprint(test.a, test.b); got != exp {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %q expected: %q",
+				n, test.opStr, got, exp))
+		}
+	}
+}
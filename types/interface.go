@@ -53,9 +53,9 @@ type Stacked interface {
 // defines common methods of  all types that can be represented as boolean
 // value and encodes as big.Int:using its bitwise methods.
 //
-//  - bool, []bool [true,false]
-//  - int, []int [-2,0,1]
-//  - uint, []uint [boolean bitwise]
+//   - bool, []bool [true,false]
+//   - int, []int [-2,0,1]
+//   - uint, []uint [boolean bitwise]
 type Flagged interface {
 	//
 	// Bool(BoolType) Evaluable (return values type will match the BITWISE set)
@@ -118,7 +118,7 @@ type Mapped interface {
 type DeDublicated interface {
 	Collected
 	Add(...Evaluable) DeDublicated
-	Remove(int) DeDublicated
+	Remove(...Evaluable) DeDublicated
 	Contains(v ...Evaluable) bool
 }
 
@@ -141,7 +141,6 @@ type NumericTabular interface {
 
 // table containing elements of symbolic value
 type SymbolicTabular interface {
-	abs() []Mapped
 	Element(Evaluable, Evaluable) Evaluable
 	Column(Evaluable) Mapped
 	Row(Evaluable) Mapped
@@ -0,0 +1,260 @@
+package types
+
+//////////////////////////////////////////////////////////////////////////
+//// LABELED TABLE
+///
+// a labeled table addresses its cells by the keys of rows and columns, which
+// are usually text, instead of their position. Cells may hold values of any
+// type. Rows and columns keep the order they got added in, a row projects to
+// a map of the column keys on to its cells and vice versa. Labeled tables are
+// immutable, all methods that alter cells, rows, or columns return a new
+// version. Nil cells are stored as empty values. As list, labeled tables
+// append and remove rows by position.
+type LabeledTable func() *labeled
+
+type labeled struct {
+	rows, cols []Evaluable
+	cells      [][]Evaluable // by row, then column
+}
+
+func (t LabeledTable) Eval() Evaluable           { return evalCollection(t) }
+func (t LabeledTable) Type() ValueType           { return TABLE }
+func (t LabeledTable) Size() int                 { return len(t().rows) * len(t().cols) }
+func (t LabeledTable) Empty() bool               { return t.Size() == 0 }
+func (t LabeledTable) Clear() Collected          { return labeledOf(nil, nil, nil) }
+func (t LabeledTable) String() string            { return string(t.Serialize()) }
+func (t LabeledTable) Interfaces() []interface{} { return interfaceSlice(t.Values()) }
+func (t LabeledTable) Shape() []int              { return []int{len(t().rows), len(t().cols)} }
+func (t LabeledTable) Dim() int                  { return 2 }
+
+// keys of the rows and columns in order
+func (t LabeledTable) RowKeys() []Evaluable    { return append([]Evaluable{}, t().rows...) }
+func (t LabeledTable) ColumnKeys() []Evaluable { return append([]Evaluable{}, t().cols...) }
+
+// cells row by row
+func (t LabeledTable) Values() []Evaluable {
+	var r = []Evaluable{}
+	for _, row := range t().cells {
+		r = append(r, row...)
+	}
+	return r
+}
+
+// one line per row, led by the column keys. Keys and cells are delimited by
+// tabs, empty cells are left blank.
+func (t LabeledTable) Serialize() []byte {
+	var r = []byte{}
+	for i, row := range append([][]Evaluable{t().cols}, t().cells...) {
+		if i > 0 {
+			r = append(r, t().rows[i-1].Serialize()...)
+		}
+		for _, v := range row {
+			r = append(r, '\t')
+			if v.Type() != EMPTY {
+				r = append(r, v.Serialize()...)
+			}
+		}
+		r = append(r, '\n')
+	}
+	return r
+}
+
+// Element returns the cell of the row and column, nil if either is missing
+func (t LabeledTable) Element(row, col Evaluable) Evaluable {
+	var i, j = keyIndex(t().rows, row), keyIndex(t().cols, col)
+	if i < 0 || j < 0 {
+		return nil
+	}
+	return t().cells[i][j]
+}
+
+// Set returns a version with the cell of the row and column replaced, false
+// if either is missing.
+func (t LabeledTable) Set(row, col, v Evaluable) (LabeledTable, bool) {
+	var i, j = keyIndex(t().rows, row), keyIndex(t().cols, col)
+	if i < 0 || j < 0 {
+		return t, false
+	}
+	var cells = t.copyCells()
+	cells[i][j] = cellOf(v)
+	return labeledOf(t().rows, t().cols, cells), true
+}
+
+// Row maps the column keys on to the cells of the row, Column the row keys
+// on to the cells of the column. Both are empty, if the key is missing.
+func (t LabeledTable) Row(k Evaluable) Mapped {
+	var m = NewHashTrieMap()
+	if i := keyIndex(t().rows, k); i >= 0 {
+		for j, c := range t().cols {
+			m = m.Put(c, t().cells[i][j]).(HashTrieMap)
+		}
+	}
+	return m
+}
+func (t LabeledTable) Column(k Evaluable) Mapped {
+	var m = NewHashTrieMap()
+	if j := keyIndex(t().cols, k); j >= 0 {
+		for i, r := range t().rows {
+			m = m.Put(r, t().cells[i][j]).(HashTrieMap)
+		}
+	}
+	return m
+}
+
+// Rows returns the rows projected to maps, in order
+func (t LabeledTable) Rows() []Mapped {
+	var r = []Mapped{}
+	for _, k := range t().rows {
+		r = append(r, t.Row(k))
+	}
+	return r
+}
+
+//// ROWS & COLUMNS
+// AddRow returns a version with a row of the passed key appended, holding the
+// passed values in order of the columns and empty values beyond. False is
+// returned, if the key exists, or there are more values than columns.
+func (t LabeledTable) AddRow(k Evaluable, v ...Evaluable) (LabeledTable, bool) {
+	if k == nil || keyIndex(t().rows, k) >= 0 || len(v) > len(t().cols) {
+		return t, false
+	}
+	var row = make([]Evaluable, len(t().cols))
+	for j := range row {
+		row[j] = emptyValue()
+		if j < len(v) {
+			row[j] = cellOf(v[j])
+		}
+	}
+	return labeledOf(append(t.RowKeys(), k), t().cols, append(t.copyCells(), row)), true
+}
+
+// AddColumn returns a version with a column of the passed key appended, see
+// AddRow.
+func (t LabeledTable) AddColumn(k Evaluable, v ...Evaluable) (LabeledTable, bool) {
+	if k == nil || keyIndex(t().cols, k) >= 0 || len(v) > len(t().rows) {
+		return t, false
+	}
+	var cells = t.copyCells()
+	for i := range cells {
+		var c = emptyValue()
+		if i < len(v) {
+			c = cellOf(v[i])
+		}
+		cells[i] = append(cells[i], c)
+	}
+	return labeledOf(t().rows, append(t.ColumnKeys(), k), cells), true
+}
+
+// RemoveRow and RemoveColumn return a version without the row, or column of
+// the passed key
+func (t LabeledTable) RemoveRow(k Evaluable) LabeledTable {
+	var i = keyIndex(t().rows, k)
+	if i < 0 {
+		return t
+	}
+	var cells = t.copyCells()
+	return labeledOf(
+		append(t.RowKeys()[:i], t().rows[i+1:]...),
+		t().cols,
+		append(cells[:i], cells[i+1:]...))
+}
+func (t LabeledTable) RemoveColumn(k Evaluable) LabeledTable {
+	var j = keyIndex(t().cols, k)
+	if j < 0 {
+		return t
+	}
+	var cells = t.copyCells()
+	for i, row := range cells {
+		cells[i] = append(row[:j], row[j+1:]...)
+	}
+	return labeledOf(t().rows, append(t.ColumnKeys()[:j], t().cols[j+1:]...), cells)
+}
+
+// Add returns a version with the passed values appended as rows, filling
+// one row after the other in order of the columns and padding the last one
+// by empty values. Each row is keyed by its position, counting from one, or
+// the next free integer beyond.
+func (t LabeledTable) Add(v ...Evaluable) Listed {
+	var n = len(t().cols)
+	if len(v) == 0 || n == 0 {
+		return t
+	}
+	var r, k = t, int64(len(t().rows))
+	for len(v) > 0 {
+		var row = v[:min(n, len(v))]
+		v = v[len(row):]
+		k++
+		for keyIndex(r().rows, NewInteger(k)) >= 0 {
+			k++
+		}
+		r, _ = r.AddRow(NewInteger(k), row...)
+	}
+	return r
+}
+
+// Remove returns a version without the row at the passed index
+func (t LabeledTable) Remove(i int) Listed {
+	if i < 0 || i >= len(t().rows) {
+		return t
+	}
+	return t.RemoveRow(t().rows[i])
+}
+
+// Matrix returns the cells as matrix of the same shape, false if any cell is
+// not numeric.
+func (t LabeledTable) Matrix() (Matrix, bool) {
+	return NewMatrix(len(t().rows), len(t().cols), t.Values()...)
+}
+
+func (t LabeledTable) copyCells() [][]Evaluable {
+	var r = make([][]Evaluable, len(t().cells))
+	for i, row := range t().cells {
+		r[i] = append([]Evaluable{}, row...)
+	}
+	return r
+}
+
+// NewLabeledTable allocates a table with columns of the passed keys and no
+// rows, false if a key is passed twice.
+func NewLabeledTable(cols ...Evaluable) (LabeledTable, bool) {
+	var t = labeledOf(nil, nil, nil)
+	for _, k := range cols {
+		var ok bool
+		if t, ok = t.AddColumn(k); !ok {
+			return nil, false
+		}
+	}
+	return t, true
+}
+func labeledOf(rows, cols []Evaluable, cells [][]Evaluable) LabeledTable {
+	var t = &labeled{rows, cols, cells}
+	return func() *labeled { return t }
+}
+
+// cells hold an empty value instead of nil
+func cellOf(v Evaluable) Evaluable {
+	if v == nil {
+		return emptyValue()
+	}
+	return v
+}
+
+// position of the equal key, -1 if there is none
+func keyIndex(keys []Evaluable, k Evaluable) int {
+	for i, x := range keys {
+		if Equal(x, k) {
+			return i
+		}
+	}
+	return -1
+}
+
+// column keys as list, followed by a pair of key and row for each row, in
+// order. Labeled tables with equal entries are equal.
+func labeledEntries(t LabeledTable) []Evaluable {
+	var r = []Evaluable{NewVector(t().cols...)}
+	for i, k := range t().rows {
+		r = append(r, pairFromValues(k, NewVector(t().cells[i]...)))
+	}
+	return r
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"testing"
+)
+
+var (
+	_ SymbolicTabular = LabeledTable(nil)
+	_ Tabular         = LabeledTable(nil)
+)
+
+// risk register with the columns probability and impact
+func riskTable() LabeledTable {
+	var t, _ = NewLabeledTable(NewText("probability"), NewText("impact"))
+	t, _ = t.AddRow(NewText("outage"), newRatio(1, 10), NewInteger(50))
+	t, _ = t.AddRow(NewText("churn"), newRatio(1, 4), NewInteger(8))
+	return t
+}
+
+// expected empty cells
+type empty struct{}
+
+func (empty) value() Evaluable { return emptyValue() }
+
+// function type, to be passed in the test slug
+type labeledTestFunc func(t LabeledTable) Evaluable
+
+// the operand is the risk register. Results of more than one value are
+// returned as list.
+var labeledTests = []struct {
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    labeledTestFunc
+}{
+	{LIST, []interface{}{[]interface{}{"a", "b"}, []interface{}{0, 2}, true, true, false}, "NewLabeledTable", func(t LabeledTable) Evaluable {
+		var l, ok = NewLabeledTable(NewText("a"), NewText("b"))
+		var _, dup = NewLabeledTable(NewText("a"), NewText("a"))
+		return NewArrayList(NewArrayList(l.ColumnKeys()...), NewArrayList(shapeOf(l)...), Value(l.Empty()), Value(ok), Value(dup))
+	}},
+	{INTEGER, 8, "Element", func(t LabeledTable) Evaluable { return t.Element(NewText("churn"), NewText("impact")) }},
+	{INTEGER, nil, "Element missing", func(t LabeledTable) Evaluable { return t.Element(NewText("x"), NewText("impact")) }},
+	{LIST, []interface{}{8, "high", true, false}, "Set", func(a LabeledTable) Evaluable {
+		var b, ok = a.Set(NewText("churn"), NewText("impact"), NewText("high"))
+		var _, missing = a.Set(NewText("churn"), NewText("cost"), NewInteger(1))
+		return NewArrayList(a.Element(NewText("churn"), NewText("impact")), b.Element(NewText("churn"), NewText("impact")), Value(ok), Value(missing))
+	}},
+	{LIST, []interface{}{2, []interface{}{"impact", "probability"}, 50, true}, "Row", func(t LabeledTable) Evaluable {
+		var r = t.Row(NewText("outage"))
+		v, _ := r.(HashTrieMap).Get(NewText("impact"))
+		return NewArrayList(NewInteger(int64(r.Size())), NewArrayList(r.Keys()...), v, Value(t.Row(NewText("x")).Empty()))
+	}},
+	{LIST, []interface{}{2, fraction("1/4")}, "Column", func(t LabeledTable) Evaluable {
+		var c = t.Column(NewText("probability"))
+		v, _ := c.(HashTrieMap).Get(NewText("churn"))
+		return NewArrayList(NewInteger(int64(c.Size())), v)
+	}},
+	{LIST, []interface{}{2, 2}, "Rows", func(t LabeledTable) Evaluable {
+		return NewArrayList(NewInteger(int64(len(t.Rows()))), NewInteger(int64(t.Rows()[1].Size())))
+	}},
+	{LIST, []interface{}{[]interface{}{"outage", "churn", "fraud"}, empty{}, true, false, false}, "AddRow", func(t LabeledTable) Evaluable {
+		var r, ok = t.AddRow(NewText("fraud"), NewInteger(1))
+		var _, dup = r.AddRow(NewText("fraud"))
+		var _, many = r.AddRow(NewText("x"), NewInteger(1), NewInteger(2), NewInteger(3))
+		return NewArrayList(NewArrayList(r.RowKeys()...), r.Element(NewText("fraud"), NewText("impact")), Value(ok), Value(dup), Value(many))
+	}},
+	{LIST, []interface{}{[]interface{}{"probability", "impact", "owner"}, "ops", empty{}, true}, "AddColumn", func(t LabeledTable) Evaluable {
+		var c, ok = t.AddColumn(NewText("owner"), NewText("ops"))
+		return NewArrayList(NewArrayList(c.ColumnKeys()...), c.Element(NewText("outage"), NewText("owner")),
+			c.Element(NewText("churn"), NewText("owner")), Value(ok))
+	}},
+	{LIST, []interface{}{empty{}, empty{}, true, true}, "nil cells", func(t LabeledTable) Evaluable {
+		var a, _ = t.AddRow(NewText("fraud"), nil, NewInteger(1))
+		var b, ok = a.Set(NewText("churn"), NewText("impact"), nil)
+		var c, _ = b.AddColumn(NewText("owner"), nil)
+		return NewArrayList(a.Element(NewText("fraud"), NewText("probability")),
+			b.Element(NewText("churn"), NewText("impact")), Value(ok), Value(len(c.String()) > 0))
+	}},
+	{LIST, []interface{}{[]interface{}{"outage", "churn", 4, 5, 6}, []interface{}{5, 2}, 3, empty{}, []interface{}{3, 2}}, "Add", func(t LabeledTable) Evaluable {
+		t, _ = t.AddRow(NewInteger(4))
+		var l = t.Add(NewInteger(1), NewInteger(2), NewInteger(3)).(LabeledTable)
+		return NewArrayList(NewArrayList(l.RowKeys()...), NewArrayList(shapeOf(l)...), l.Element(NewInteger(6), NewText("probability")),
+			l.Element(NewInteger(6), NewText("impact")), NewArrayList(shapeOf(t)...))
+	}},
+	{INTEGER, 0, "Add without columns", func(t LabeledTable) Evaluable {
+		t, _ = NewLabeledTable()
+		return NewInteger(int64(t.Add(NewInteger(1)).Size()))
+	}},
+	{LIST, []interface{}{[]interface{}{"churn"}, 4, 4}, "Remove", func(t LabeledTable) Evaluable {
+		return NewArrayList(NewArrayList(t.Remove(0).(LabeledTable).RowKeys()...),
+			NewInteger(int64(t.Remove(2).Size())), NewInteger(int64(t.Remove(-1).Size())))
+	}},
+	{LIST, []interface{}{[]interface{}{"outage", "churn"}, []interface{}{"churn"}, 8, []interface{}{2, 2}}, "RemoveRow", func(a LabeledTable) Evaluable {
+		var b = a.RemoveRow(NewText("outage"))
+		return NewArrayList(NewArrayList(a.RowKeys()...), NewArrayList(b.RowKeys()...),
+			b.Element(NewText("churn"), NewText("impact")), NewArrayList(shapeOf(a.RemoveRow(NewText("x")))...))
+	}},
+	{LIST, []interface{}{[]interface{}{"impact"}, []interface{}{50, 8}}, "RemoveColumn", func(t LabeledTable) Evaluable {
+		t = t.RemoveColumn(NewText("probability"))
+		return NewArrayList(NewArrayList(t.ColumnKeys()...), NewArrayList(t.Values()...))
+	}},
+	{TEXT, "\tprobability\timpact\towner\noutage\t1/10\t50\tops\nchurn\t1/4\t8\t\n", "Serialize", func(t LabeledTable) Evaluable {
+		t, _ = t.AddColumn(NewText("owner"), NewText("ops"))
+		return NewText(t.String())
+	}},
+	{LIST, []interface{}{true, []interface{}{2, 2}, matrixCells{2, 1, []fraction{"50", "8"}}}, "Matrix", func(t LabeledTable) Evaluable {
+		var m, ok = t.Matrix()
+		var s, _ = m.Times(intMatrix(2, 1, 0, 1))
+		return NewArrayList(Value(ok), NewArrayList(shapeOf(m)...), s)
+	}},
+	{BOOL, false, "Matrix symbolic", func(t LabeledTable) Evaluable {
+		t, _ = t.AddColumn(NewText("owner"), NewText("ops"), NewText("sales"))
+		var _, ok = t.Matrix()
+		return Value(ok)
+	}},
+	{LIST, []interface{}{true, false, true, -1}, "Equal", func(a LabeledTable) Evaluable {
+		var b = riskTable()
+		var c, _ = b.Set(NewText("churn"), NewText("impact"), NewInteger(9))
+		return NewArrayList(Value(Equal(a, b)), Value(Equal(a, c)), Value(Hash(a) == Hash(b)), NewInteger(int64(Compare(a, c))))
+	}},
+}
+
+func TestLabeledTable(t *testing.T) {
+	for n, test := range labeledTests {
+		testValue(t, n, test.opStr, test.op(riskTable()), test.typ, nativeValue(test.exp))
+	}
+}
@@ -2,7 +2,6 @@ package types
 
 import (
 	con "github.com/emirpasic/gods/containers"
-	"math/big"
 )
 
 // lists and sublists of exactly two values length, are assumed to be either
@@ -22,21 +21,21 @@ import (
 // To prevent code repetition, most methods wrap private methods defined at
 // module level, that take implementations of public interfaces defined by the
 // package. as their arguments and return values.
-func (l ArrayList) Eval() Evaluable  { return evalCollection(l()) }
+func (l ArrayList) Eval() Evaluable  { return evalCollection(l) }
 func (l ArrayList) Size() int        { return collectionSize(l()) }
 func (l ArrayList) Empty() bool      { return emptyCollection(l()) }
-func (l ArrayList) Clear() Collected { return clearCollection(l()) }
+func (l ArrayList) Clear() Collected { return clearCollection(l, l()) }
 
-func (l ArrayList) Get(i int) (Evaluable, bool) { return getFromList(l, i) }
-func (l ArrayList) Remove(i int) Listed         { return removeFromList(l, i) }
-func (l ArrayList) Add(v ...Evaluable) Listed   { return addToList(l, v...) }
+func (l ArrayList) Get(i int) (Evaluable, bool) { return getFromList(l(), i) }
+func (l ArrayList) Remove(i int) Listed         { return removeFromList(l, l(), i) }
+func (l ArrayList) Add(v ...Evaluable) Listed   { return addToList(l, l(), v...) }
 func (l ArrayList) AddInterface(v ...interface{}) Listed {
-	return addSliceOfInterfacesToList(l, v...)
+	return addSliceOfInterfacesToList(l, l(), v...)
 }
-func (l ArrayList) Contains(v ...Evaluable) bool        { return listContains(l, v...) }
-func (l ArrayList) Sort(c Compareable) Listed           { return sortList(l, c) }
-func (l ArrayList) Swap(idx int, idy int) Listed        { return swapList(l, idx, idy) }
-func (l ArrayList) Insert(i int, v ...Evaluable) Listed { return insertList(l, i, v...) }
+func (l ArrayList) Contains(v ...Evaluable) bool        { return listContains(l(), v...) }
+func (l ArrayList) Sort(c Compareable) Listed           { return sortList(l, l(), c) }
+func (l ArrayList) Swap(idx int, idy int) Listed        { return swapList(l, l(), idx, idy) }
+func (l ArrayList) Insert(i int, v ...Evaluable) Listed { return insertList(l, l(), i, v...) }
 func (l ArrayList) Values() []Evaluable                 { return collectionValues(l()) }
 func (l ArrayList) Interfaces() []interface{}           { return collectionInterfaces(l()) }
 func (l ArrayList) Serialize() []byte                   { return serializeList(l) }
@@ -93,21 +92,21 @@ func (l ArrayList) Enum() Enumerable {
 }
 
 //////////////////////////////////////////////////////////////////////////
-func (l SLList) Eval() Evaluable  { return evalCollection(l()) }
+func (l SLList) Eval() Evaluable  { return evalCollection(l) }
 func (l SLList) Size() int        { return collectionSize(l()) }
 func (l SLList) Empty() bool      { return emptyCollection(l()) }
-func (l SLList) Clear() Collected { return clearCollection(l()) }
+func (l SLList) Clear() Collected { return clearCollection(l, l()) }
 
-func (l SLList) Get(i int) (Evaluable, bool) { return getFromList(l, i) }
-func (l SLList) Remove(i int) Listed         { return removeFromList(l, i) }
-func (l SLList) Add(v ...Evaluable) Listed   { return addToList(l, v...) }
+func (l SLList) Get(i int) (Evaluable, bool) { return getFromList(l(), i) }
+func (l SLList) Remove(i int) Listed         { return removeFromList(l, l(), i) }
+func (l SLList) Add(v ...Evaluable) Listed   { return addToList(l, l(), v...) }
 func (l SLList) AddInterface(v ...interface{}) Listed {
-	return addSliceOfInterfacesToList(l, v...)
+	return addSliceOfInterfacesToList(l, l(), v...)
 }
-func (l SLList) Contains(v ...Evaluable) bool        { return listContains(l, v...) }
-func (l SLList) Sort(c Compareable) Listed           { return sortList(l, c) }
-func (l SLList) Swap(idx int, idy int) Listed        { return swapList(l, idx, idy) }
-func (l SLList) Insert(i int, v ...Evaluable) Listed { return insertList(l, i, v...) }
+func (l SLList) Contains(v ...Evaluable) bool        { return listContains(l(), v...) }
+func (l SLList) Sort(c Compareable) Listed           { return sortList(l, l(), c) }
+func (l SLList) Swap(idx int, idy int) Listed        { return swapList(l, l(), idx, idy) }
+func (l SLList) Insert(i int, v ...Evaluable) Listed { return insertList(l, l(), i, v...) }
 func (l SLList) Values() []Evaluable                 { return collectionValues(l()) }
 func (l SLList) Interfaces() []interface{}           { return collectionInterfaces(l()) }
 func (l SLList) Serialize() []byte                   { return serializeList(l) }
@@ -123,21 +122,21 @@ func (l SLList) Enum() Enumerable {
 }
 
 ////////////////////////////////////////////////////////////////////////////////////
-func (l DLList) Eval() Evaluable  { return evalCollection(l()) }
+func (l DLList) Eval() Evaluable  { return evalCollection(l) }
 func (l DLList) Size() int        { return collectionSize(l()) }
 func (l DLList) Empty() bool      { return emptyCollection(l()) }
-func (l DLList) Clear() Collected { return clearCollection(l()) }
+func (l DLList) Clear() Collected { return clearCollection(l, l()) }
 
-func (l DLList) Get(i int) (Evaluable, bool) { return getFromList(l, i) }
-func (l DLList) Remove(i int) Listed         { return removeFromList(l, i) }
-func (l DLList) Add(v ...Evaluable) Listed   { return addToList(l, v...) }
+func (l DLList) Get(i int) (Evaluable, bool) { return getFromList(l(), i) }
+func (l DLList) Remove(i int) Listed         { return removeFromList(l, l(), i) }
+func (l DLList) Add(v ...Evaluable) Listed   { return addToList(l, l(), v...) }
 func (l DLList) AddInterface(v ...interface{}) Listed {
-	return addSliceOfInterfacesToList(l, v...)
+	return addSliceOfInterfacesToList(l, l(), v...)
 }
-func (l DLList) Contains(v ...Evaluable) bool        { return listContains(l, v...) }
-func (l DLList) Sort(c Compareable) Listed           { return sortList(l, c) }
-func (l DLList) Swap(idx int, idy int) Listed        { return swapList(l, idx, idy) }
-func (l DLList) Insert(i int, v ...Evaluable) Listed { return insertList(l, i, v...) }
+func (l DLList) Contains(v ...Evaluable) bool        { return listContains(l(), v...) }
+func (l DLList) Sort(c Compareable) Listed           { return sortList(l, l(), c) }
+func (l DLList) Swap(idx int, idy int) Listed        { return swapList(l, l(), idx, idy) }
+func (l DLList) Insert(i int, v ...Evaluable) Listed { return insertList(l, l(), i, v...) }
 func (l DLList) Values() []Evaluable                 { return collectionValues(l()) }
 func (l DLList) Interfaces() []interface{}           { return collectionInterfaces(l()) }
 func (l DLList) Serialize() []byte                   { return serializeList(l) }
@@ -151,42 +150,3 @@ func (l DLList) Enum() Enumerable {
 	var r IdxEnumerable = func() con.EnumerableWithIndex { return l() }
 	return r
 }
-
-//////////////////////////////////////////////////////////////////////////
-//////////////////////////////////////////////////////////////////////////
-func (f BitFlag) Eval() Evaluable { return Value(f) }
-
-// uses byte method of contained big int
-func (f BitFlag) Serialize() []byte { return f().Bytes() }
-
-// returns Flag converted to string on base two
-func (f BitFlag) String() string { return f().Text(2) }
-
-// returns pure type Flag
-func (f BitFlag) Type() ValueType { return FLAG }
-
-func (f BitFlag) Empty() bool {
-	if f().Cmp(ZERO.Flag()) > 0 {
-		return false
-	} else {
-		return true
-	}
-}
-func (f BitFlag) Size() int                  { return f().BitLen() }
-func (f BitFlag) Or(v Evaluable) (r BitFlag) { return r }
-func (f BitFlag) Clear() Collected {
-	var r *big.Int = f()
-	r.Set(ZERO.Flag())
-	return BitFlag(func() *big.Int { return r })
-}
-func (f BitFlag) Values() []Evaluable {
-	return valueSlice(f.Values())
-}
-func (f BitFlag) Interfaces() []interface{} {
-	var v []interface{}
-	for _, val := range f().Bits() {
-		val := val
-		v = append(v, val)
-	}
-	return v
-}
@@ -0,0 +1,377 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+)
+
+//////////////////////////////////////////////////////////////////////////
+//// EXACT MATRIX
+///
+// matrices hold rational elements in row major order and compute exactly,
+// eliminating by fractions instead of floats, so that neither sums, products,
+// nor inverses and solutions drift. Elements are returned as Ratio, all
+// numeric values can be passed in, floats are taken by their exact value.
+// Matrices are immutable, all operations return a new matrix.
+type Matrix func() *matrix
+
+type matrix struct {
+	rows, cols int
+	cells      []*big.Rat
+}
+
+func (m Matrix) Eval() Evaluable           { return evalCollection(m) }
+func (m Matrix) Type() ValueType           { return MATRIX }
+func (m Matrix) Size() int                 { return len(m().cells) }
+func (m Matrix) Empty() bool               { return len(m().cells) == 0 }
+func (m Matrix) Clear() Collected          { return matrixOf(0, m().cols) }
+func (m Matrix) Serialize() []byte         { return serializeList(m) }
+func (m Matrix) String() string            { return listToString(m) }
+func (m Matrix) Interfaces() []interface{} { return interfaceSlice(m.Values()) }
+func (m Matrix) Shape() []int              { return []int{m().rows, m().cols} }
+func (m Matrix) Dim() int                  { return 2 }
+
+// elements in row major order
+func (m Matrix) Values() []Evaluable {
+	var r = []Evaluable{}
+	for _, c := range m().cells {
+		r = append(r, ratioFrom(c))
+	}
+	return r
+}
+
+// Element returns the element of row x and column y, nil if either is out of
+// range.
+func (m Matrix) Element(x int, y int) Evaluable {
+	if x < 0 || x >= m().rows || y < 0 || y >= m().cols {
+		return nil
+	}
+	return ratioFrom(m().cells[x*m().cols+y])
+}
+
+// Row and Column return the elements of a row, or column as vector, which is
+// empty, if the index is out of range.
+func (m Matrix) Row(i int) Listed {
+	var r = []Evaluable{}
+	for j := 0; i >= 0 && i < m().rows && j < m().cols; j++ {
+		r = append(r, m.Element(i, j))
+	}
+	return NewVector(r...)
+}
+func (m Matrix) Column(i int) Listed {
+	var r = []Evaluable{}
+	for j := 0; i >= 0 && i < m().cols && j < m().rows; j++ {
+		r = append(r, m.Element(j, i))
+	}
+	return NewVector(r...)
+}
+
+// Add returns a version with the passed values appended as rows, the last one
+// padded by zeros. If any value is not numeric, the matrix is returned
+// unchanged.
+func (m Matrix) Add(v ...Evaluable) Listed {
+	if len(v) == 0 || m().cols == 0 {
+		return m
+	}
+	var rows = (len(v) + m().cols - 1) / m().cols
+	var r = matrixOf(m().rows+rows, m().cols)
+	copyRats(r().cells, m().cells)
+	if !setRats(r().cells[len(m().cells):], v...) {
+		return m
+	}
+	return r
+}
+
+// Remove returns a version without the row at the passed index
+func (m Matrix) Remove(i int) Listed {
+	if i < 0 || i >= m().rows {
+		return m
+	}
+	var r = matrixOf(m().rows-1, m().cols)
+	copyRats(r().cells, m().cells[:i*m().cols])
+	copyRats(r().cells[i*m().cols:], m().cells[(i+1)*m().cols:])
+	return r
+}
+
+// Set returns a version with the element of row x and column y replaced,
+// false if either is out of range, or the value is not numeric.
+func (m Matrix) Set(e Evaluable, x, y int) (Matrix, bool) {
+	if x < 0 || x >= m().rows || y < 0 || y >= m().cols {
+		return m, false
+	}
+	var r = matrixOf(m().rows, m().cols)
+	copyRats(r().cells, m().cells)
+	if !setRats(r().cells[x*m().cols+y:][:1], e) {
+		return m, false
+	}
+	return r, true
+}
+
+// Table returns a table of the same shape, holding the elements
+func (m Matrix) Table() Table { return tableOf(m.Shape(), m.Values()) }
+
+//// ARITHMETIC
+// sums and products are false, if the shapes don't match
+func (m Matrix) Plus(n Matrix) (Matrix, bool)  { return m.elementwise(n, (*big.Rat).Add) }
+func (m Matrix) Minus(n Matrix) (Matrix, bool) { return m.elementwise(n, (*big.Rat).Sub) }
+func (m Matrix) elementwise(n Matrix, op func(z, x, y *big.Rat) *big.Rat) (Matrix, bool) {
+	if m().rows != n().rows || m().cols != n().cols {
+		return m, false
+	}
+	var r = matrixOf(m().rows, m().cols)
+	for i, c := range r().cells {
+		op(c, m().cells[i], n().cells[i])
+	}
+	return r, true
+}
+
+// Times returns the matrix product, which needs the number of columns of m to
+// match the number of rows of n.
+func (m Matrix) Times(n Matrix) (Matrix, bool) {
+	if m().cols != n().rows {
+		return m, false
+	}
+	var r = matrixOf(m().rows, n().cols)
+	var p = new(big.Rat)
+	for i := 0; i < m().rows; i++ {
+		for j := 0; j < n().cols; j++ {
+			var c = r().cells[i*n().cols+j]
+			for k := 0; k < m().cols; k++ {
+				c.Add(c, p.Mul(m().cells[i*m().cols+k], n().cells[k*n().cols+j]))
+			}
+		}
+	}
+	return r, true
+}
+
+// Scale multiplies all elements by the passed number
+func (m Matrix) Scale(v Evaluable) (Matrix, bool) {
+	var f, ok = bigRatOf(v)
+	if !ok {
+		return m, false
+	}
+	var r = matrixOf(m().rows, m().cols)
+	for i, c := range r().cells {
+		c.Mul(m().cells[i], f)
+	}
+	return r, true
+}
+
+func (m Matrix) Transpose() Matrix {
+	var r = matrixOf(m().cols, m().rows)
+	for i := 0; i < m().rows; i++ {
+		for j := 0; j < m().cols; j++ {
+			r().cells[j*m().rows+i].Set(m().cells[i*m().cols+j])
+		}
+	}
+	return r
+}
+
+//// GAUSSIAN ELIMINATION
+// Det returns the determinant, false if the matrix is not square
+func (m Matrix) Det() (Ratio, bool) {
+	if m().rows != m().cols {
+		return nil, false
+	}
+	var pivots, det = reduce(m.augment(), m().cols)
+	if len(pivots) < m().rows {
+		det.SetInt64(0)
+	}
+	return wrap(det).(Ratio), true
+}
+
+// Rank returns the number of linear independent rows
+func (m Matrix) Rank() int {
+	var pivots, _ = reduce(m.augment(), m().cols)
+	return len(pivots)
+}
+
+// Inverse returns the inverse matrix, false if the matrix is not square, or
+// singular.
+func (m Matrix) Inverse() (Matrix, bool) {
+	if m().rows != m().cols {
+		return m, false
+	}
+	var x, ok = m.Solve(Identity(m().rows))
+	return x, ok
+}
+
+// Solve returns x, so that m·x equals b. Each column of b is solved for
+// separately. False is returned, if the number of rows differ, the system
+// has no solution, or no unique one.
+func (m Matrix) Solve(b Matrix) (Matrix, bool) {
+	if m().rows != b().rows {
+		return m, false
+	}
+	var rows = m.augment(b)
+	var pivots, _ = reduce(rows, m().cols)
+	if len(pivots) < m().cols {
+		return m, false
+	}
+	// rows without pivot are all zero on the left side, so they need to be
+	// on the right side as well
+	for _, row := range rows[len(pivots):] {
+		for _, c := range row[m().cols:] {
+			if c.Sign() != 0 {
+				return m, false
+			}
+		}
+	}
+	var r = matrixOf(m().cols, b().cols)
+	for i := 0; i < m().cols; i++ {
+		copyRats(r().cells[i*b().cols:], rows[i][m().cols:])
+	}
+	return r, true
+}
+
+// copies of the rows, with the rows of the passed matrices appended
+func (m Matrix) augment(n ...Matrix) [][]*big.Rat {
+	var r = make([][]*big.Rat, m().rows)
+	for i := range r {
+		for _, x := range append([]Matrix{m}, n...) {
+			for _, c := range x().cells[i*x().cols : (i+1)*x().cols] {
+				r[i] = append(r[i], new(big.Rat).Set(c))
+			}
+		}
+	}
+	return r
+}
+
+// reduces the rows in place to reduced row echelon form by Gauss-Jordan
+// elimination, choosing pivots within the first n columns only. Returns the
+// columns of the pivots and the product of the pivots, negated for each swap
+// of rows, which is the determinant, if the first n columns are square and of
+// full rank.
+func reduce(rows [][]*big.Rat, n int) (pivots []int, det *big.Rat) {
+	det = big.NewRat(1, 1)
+	var f, p = new(big.Rat), new(big.Rat)
+	for c := 0; c < n && len(pivots) < len(rows); c++ {
+		var r = len(pivots)
+		var s = r
+		for s < len(rows) && rows[s][c].Sign() == 0 {
+			s++
+		}
+		if s == len(rows) {
+			continue
+		}
+		if s != r {
+			rows[s], rows[r] = rows[r], rows[s]
+			det.Neg(det)
+		}
+		det.Mul(det, rows[r][c])
+		f.Inv(rows[r][c])
+		for _, x := range rows[r] {
+			x.Mul(x, f)
+		}
+		for i, row := range rows {
+			if i == r || row[c].Sign() == 0 {
+				continue
+			}
+			f.Set(row[c])
+			for j, x := range row {
+				x.Sub(x, p.Mul(f, rows[r][j]))
+			}
+		}
+		pivots = append(pivots, c)
+	}
+	return pivots, det
+}
+
+//// ALLOCATION
+// NewMatrix allocates a matrix of the passed number of rows and columns,
+// filled with the passed values in row major order and zeros beyond. False
+// is returned, if rows, or columns are negative, there are more values than
+// elements, or a value is not numeric.
+func NewMatrix(rows, cols int, v ...Evaluable) (Matrix, bool) {
+	if rows < 0 || cols < 0 || len(v) > rows*cols {
+		return nil, false
+	}
+	var m = matrixOf(rows, cols)
+	if !setRats(m().cells, v...) {
+		return nil, false
+	}
+	return m, true
+}
+
+// Identity allocates the identity matrix of n rows and columns
+func Identity(n int) Matrix {
+	var m = matrixOf(n, n)
+	for i := 0; i < n; i++ {
+		m().cells[i*n+i].SetInt64(1)
+	}
+	return m
+}
+
+// zero matrix
+func matrixOf(rows, cols int) Matrix {
+	var m = &matrix{rows, cols, make([]*big.Rat, rows*cols)}
+	for i := range m.cells {
+		m.cells[i] = new(big.Rat)
+	}
+	return func() *matrix { return m }
+}
+
+// sets the cells to the values, false if one of them is not numeric
+func setRats(cells []*big.Rat, v ...Evaluable) bool {
+	for i, v := range v {
+		if v == nil || scalarType(v)&NUMERIC == 0 {
+			return false
+		}
+		var r, ok = bigRatOf(v)
+		if !ok {
+			return false
+		}
+		cells[i].Set(r)
+	}
+	return true
+}
+func copyRats(dst, src []*big.Rat) {
+	for i, c := range src {
+		dst[i].Set(c)
+	}
+}
+
+// elements are handed out as copies, to keep the matrix unchanged
+func ratioFrom(r *big.Rat) Ratio { return wrap(newRat().Set(r)).(Ratio) }
+
+// arithmetic dispatched on matrices: matrices of matching shape get added,
+// subtracted and multiplied, numbers multiply all elements and divide them,
+// if the matrix is the dividend.
+func arithMatrix(op arithOp, a, b Evaluable) (Evaluable, error) {
+	var x, okx = a.(Matrix)
+	var y, oky = b.(Matrix)
+	var r Matrix
+	var ok bool
+	switch {
+	case okx && oky && op == opAdd:
+		r, ok = x.Plus(y)
+	case okx && oky && op == opSub:
+		r, ok = x.Minus(y)
+	case okx && oky && op == opMul:
+		r, ok = x.Times(y)
+	case okx && op == opMul && scalarType(b)&NUMERIC != 0:
+		r, ok = x.Scale(b)
+	case oky && op == opMul && scalarType(a)&NUMERIC != 0:
+		r, ok = y.Scale(a)
+	case okx && op == opQuo && scalarType(b)&NUMERIC != 0:
+		var d, okd = bigRatOf(b)
+		if okd && d.Sign() == 0 {
+			return nil, fmt.Errorf("types: division by zero")
+		}
+		if okd {
+			r, ok = x.Scale(wrap(newRat().Inv(d)))
+		}
+	default:
+		return nil, fmt.Errorf("types: can not %s %s and %s",
+			opNames[op], scalarType(a), scalarType(b))
+	}
+	switch {
+	case !ok && okx && oky:
+		return nil, fmt.Errorf("types: can not %s matrices of shape %v and %v",
+			opNames[op], x.Shape(), y.Shape())
+	case !ok:
+		return nil, fmt.Errorf("types: can not %s %s and %s",
+			opNames[op], scalarType(a), scalarType(b))
+	}
+	return r, nil
+}
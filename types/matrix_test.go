@@ -0,0 +1,177 @@
+package types
+
+import (
+	"testing"
+)
+
+var (
+	_ NumericTabular = Matrix(nil)
+	_ Tabular        = Matrix(nil)
+)
+
+// matrix of the passed shape, holding the passed integers
+func intMatrix(rows, cols int, v ...int64) Matrix {
+	var e = []Evaluable{}
+	for _, v := range v {
+		e = append(e, NewInteger(v))
+	}
+	var m, _ = NewMatrix(rows, cols, e...)
+	return m
+}
+
+// expected ratios are passed as fraction, or integer string
+type fraction string
+
+func (f fraction) value() Evaluable { return parseRatio(string(f)) }
+
+// expected matrices are passed as shape and elements in row major order
+type matrixCells struct {
+	rows, cols int
+	v          []fraction
+}
+
+func (c matrixCells) value() Evaluable {
+	var e = []Evaluable{}
+	for _, f := range c.v {
+		e = append(e, f.value())
+	}
+	var m, _ = NewMatrix(c.rows, c.cols, e...)
+	return m
+}
+
+// function type, to be passed in the test slug
+type matrixTestFunc func(a Matrix) Evaluable
+
+// the operand is passed as shape and integers in row major order. Results of
+// more than one value are returned as list.
+var matrixTests = []struct {
+	rows, cols int
+	a          []int64
+	typ        ValueType
+	exp        interface{}
+	opStr      string
+	op         matrixTestFunc
+}{
+	{2, 2, nil, MATRIX, matrixCells{2, 2, []fraction{"1", "1/2", "1/4", "0"}}, "NewMatrix", func(a Matrix) Evaluable {
+		a, _ = NewMatrix(2, 2, NewInteger(1), newRatio(1, 2), parseDecimal("0.25"))
+		return a
+	}},
+	{0, 0, nil, LIST, []interface{}{false, false, false}, "NewMatrix invalid", func(a Matrix) Evaluable {
+		_, text := NewMatrix(1, 1, NewText("1"))
+		_, many := NewMatrix(1, 1, NewInteger(1), NewInteger(2))
+		_, neg := NewMatrix(-1, 1)
+		return NewArrayList(Value(text), Value(many), Value(neg))
+	}},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, RATIONAL, fraction("6"), "Element", func(a Matrix) Evaluable { return a.Element(1, 2) }},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, RATIONAL, nil, "Element out of range", func(a Matrix) Evaluable { return a.Element(2, 0) }},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, LIST, []interface{}{fraction("4"), fraction("5"), fraction("6")}, "Row", func(a Matrix) Evaluable {
+		return NewArrayList(a.Row(1).Values()...)
+	}},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, LIST, []interface{}{fraction("3"), fraction("6")}, "Column", func(a Matrix) Evaluable {
+		return NewArrayList(a.Column(2).Values()...)
+	}},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, INTEGER, 0, "Column out of range", func(a Matrix) Evaluable { return NewInteger(int64(a.Column(3).Size())) }},
+	{1, 2, []int64{1, 2}, MATRIX, matrixCells{3, 2, []fraction{"1", "2", "3", "4", "5", "0"}}, "Add rows", func(a Matrix) Evaluable {
+		return a.Add(NewInteger(3), NewInteger(4), NewInteger(5)).(Matrix)
+	}},
+	{1, 2, []int64{1, 2}, MATRIX, matrixCells{2, 2, []fraction{"3", "4", "5", "0"}}, "Remove rows", func(a Matrix) Evaluable {
+		return a.Add(NewInteger(3), NewInteger(4), NewInteger(5)).(Matrix).Remove(0).(Matrix)
+	}},
+	{2, 2, []int64{1, 2, 3, 4}, LIST, []interface{}{matrixCells{2, 2, []fraction{"1", "2", "3", "4"}}, matrixCells{2, 2, []fraction{"1", "2/3", "3", "4"}}, true},
+		"Set", func(a Matrix) Evaluable {
+			var b, ok = a.Set(newRatio(2, 3), 0, 1)
+			return NewArrayList(a, b, Value(ok))
+		}},
+	{2, 2, []int64{1, 2, 3, 4}, LIST, []interface{}{matrixCells{2, 2, []fraction{"5", "5", "5", "5"}}, matrixCells{2, 2, []fraction{"-3", "-1", "1", "3"}}, false},
+		"Plus Minus", func(a Matrix) Evaluable {
+			var b = intMatrix(2, 2, 4, 3, 2, 1)
+			var s, _ = a.Plus(b)
+			var d, _ = a.Minus(b)
+			var _, ok = a.Plus(intMatrix(1, 2, 1, 2))
+			return NewArrayList(s, d, Value(ok))
+		}},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, MATRIX, matrixCells{2, 2, []fraction{"58", "64", "139", "154"}}, "Times", func(a Matrix) Evaluable {
+		var p, _ = a.Times(intMatrix(3, 2, 7, 8, 9, 10, 11, 12))
+		return p
+	}},
+	{2, 3, []int64{1, 2, 3, 4, 5, 6}, MATRIX, matrixCells{3, 2, []fraction{"1", "4", "2", "5", "3", "6"}}, "Transpose", func(a Matrix) Evaluable {
+		return a.Transpose()
+	}},
+	{3, 3, []int64{2, 0, 1, 1, 3, 2, 1, 1, 2}, LIST, []interface{}{fraction("6"), true}, "Det", func(a Matrix) Evaluable {
+		var d, ok = a.Det()
+		return NewArrayList(d, Value(ok))
+	}},
+	{2, 2, []int64{0, 1, 1, 0}, RATIONAL, fraction("-1"), "Det swap", func(a Matrix) Evaluable { d, _ := a.Det(); return d }},
+	{2, 2, []int64{1, 2, 2, 4}, RATIONAL, fraction("0"), "Det singular", func(a Matrix) Evaluable { d, _ := a.Det(); return d }},
+	{2, 2, nil, RATIONAL, fraction("-7/120"), "Det fractions", func(a Matrix) Evaluable {
+		a, _ = NewMatrix(2, 2, newRatio(1, 3), newRatio(1, 2), newRatio(1, 4), newRatio(1, 5))
+		d, _ := a.Det()
+		return d
+	}},
+	{2, 3, nil, BOOL, false, "Det not square", func(a Matrix) Evaluable { _, ok := a.Det(); return Value(ok) }},
+	{3, 3, []int64{1, 2, 3, 2, 4, 6, 1, 0, 1}, INTEGER, 2, "Rank", func(a Matrix) Evaluable { return NewInteger(int64(a.Rank())) }},
+	{2, 2, nil, INTEGER, 0, "Rank zero", func(a Matrix) Evaluable { return NewInteger(int64(a.Rank())) }},
+	{0, 0, nil, INTEGER, 4, "Rank Identity", func(a Matrix) Evaluable { return NewInteger(int64(Identity(4).Rank())) }},
+	{2, 2, []int64{4, 7, 2, 6}, LIST, []interface{}{matrixCells{2, 2, []fraction{"3/5", "-7/10", "-1/5", "2/5"}}, true, true},
+		"Inverse", func(a Matrix) Evaluable {
+			var i, ok = a.Inverse()
+			var p, _ = a.Times(i)
+			return NewArrayList(i, Value(ok), Value(Equal(p, Identity(2))))
+		}},
+	{2, 2, []int64{1, 2, 2, 4}, BOOL, false, "Inverse singular", func(a Matrix) Evaluable { _, ok := a.Inverse(); return Value(ok) }},
+	// 2x + y - z = 8, -3x - y + 2z = -11, -2x + y + 2z = -3
+	{3, 3, []int64{2, 1, -1, -3, -1, 2, -2, 1, 2}, LIST, []interface{}{matrixCells{3, 1, []fraction{"2", "3", "-1"}}, true},
+		"Solve", func(a Matrix) Evaluable {
+			var x, ok = a.Solve(intMatrix(3, 1, 8, -11, -3))
+			return NewArrayList(x, Value(ok))
+		}},
+	// weights of three criteria summing up to one, with the first weighing
+	// twice the second and the second three times the third
+	{3, 3, []int64{1, 1, 1, 1, -2, 0, 0, 1, -3}, MATRIX, matrixCells{3, 1, []fraction{"3/5", "3/10", "1/10"}}, "Solve exact", func(a Matrix) Evaluable {
+		var x, _ = a.Solve(intMatrix(3, 1, 1, 0, 0))
+		return x
+	}},
+	{3, 2, []int64{1, 0, 0, 1, 1, 1}, LIST, []interface{}{matrixCells{2, 1, []fraction{"1", "2"}}, true, false},
+		"Solve overdetermined", func(a Matrix) Evaluable {
+			var x, ok = a.Solve(intMatrix(3, 1, 1, 2, 3))
+			var _, inconsistent = a.Solve(intMatrix(3, 1, 1, 2, 4))
+			return NewArrayList(x, Value(ok), Value(inconsistent))
+		}},
+	{1, 2, []int64{1, 1}, BOOL, false, "Solve underdetermined", func(a Matrix) Evaluable { _, ok := a.Solve(intMatrix(1, 1, 1)); return Value(ok) }},
+	{1, 2, []int64{1, 2}, MATRIX, matrixCells{1, 1, []fraction{"11"}}, "Mul dispatch", func(a Matrix) Evaluable {
+		var r, _ = Mul(a, intMatrix(2, 1, 3, 4))
+		return r
+	}},
+	{1, 2, []int64{1, 2}, MATRIX, matrixCells{1, 2, []fraction{"1/2", "1"}}, "Mul scalar", func(a Matrix) Evaluable {
+		var r, _ = Mul(newRatio(1, 2), a)
+		return r
+	}},
+	{1, 2, []int64{1, 2}, MATRIX, matrixCells{1, 2, []fraction{"1/4", "1/2"}}, "Quo scalar", func(a Matrix) Evaluable {
+		var r, _ = Quo(a, NewInteger(4))
+		return r
+	}},
+	{1, 2, []int64{1, 2}, MATRIX, matrixCells{1, 2, []fraction{"2", "4"}}, "Add dispatch", func(a Matrix) Evaluable {
+		var r, _ = Add(a, a)
+		return r
+	}},
+	{1, 2, []int64{1, 2}, TEXT, "types: can not add matrices of shape [1 2] and [2 1]", "Add shapes differ", func(a Matrix) Evaluable {
+		var _, err = Add(a, intMatrix(2, 1, 1, 2))
+		return NewText(err.Error())
+	}},
+	{1, 1, []int64{1}, TEXT, "types: division by zero", "Quo zero", func(a Matrix) Evaluable {
+		var _, err = Quo(a, NewInteger(0))
+		return NewText(err.Error())
+	}},
+	{1, 2, []int64{1, 1}, LIST, []interface{}{false, false, true}, "Equal", func(b Matrix) Evaluable {
+		var a, _ = NewMatrix(1, 2, parseDecimal("0.5"), NewInteger(1))
+		return NewArrayList(Value(Equal(a, b)), Value(Equal(a, intMatrix(2, 1))), Value(Equal(a.Transpose().Transpose(), a)))
+	}},
+	{2, 2, []int64{1, 2, 3, 4}, TABLE, tableCells{[]int{2, 2}, []interface{}{fraction("1"), fraction("2"), fraction("3"), fraction("4")}},
+		"Table", func(a Matrix) Evaluable { return a.Table() }},
+}
+
+func TestMatrix(t *testing.T) {
+	for n, test := range matrixTests {
+		testValue(t, n, test.opStr, test.op(intMatrix(test.rows, test.cols, test.a...)), test.typ, nativeValue(test.exp))
+	}
+}
@@ -1,6 +1,8 @@
 package types
 
-import ()
+import (
+	"math/big"
+)
 
 /////////////////////////////////////////////////
 /////// PAIR ////////////////////////////////////
@@ -17,29 +19,25 @@ func (b Pair) Key() Evaluable { return b()[0].Eval() }
 // convertable, otherwise return a negative integer to indicate that the key is
 // not convertable to a Number
 func (b Pair) Index() Integer {
-	var ret Integer
-	if b.Key().Type()&SYMBOLIC != 0 {
-		ret = Value(-1).(Integer) // negative → not set
-	} else { // NUMERIC
-		// if natural number, return as interger
-		if b.Key().Type()&NATURAL != 0 {
-			ret = b.Key().(Integer)
+	var ret = Value(-1).(val).Integer() // negative → not set
+	var k = b.Key()
+	switch {
+	case k.Type()&SYMBOLIC != 0: // symbolic keys are no index
+	case k.Type()&NATURAL != 0: // if natural number, return as interger
+		if i, ok := bigIntOf(k); ok {
+			ret = wrap(intPool.Get().(*big.Int).Set(i)).(val).Integer()
 		}
-		// if real number, return numerator as interger
-		if b.Key().Type()&REAL != 0 {
-			ret = b.Key().(ratio).Num()
+	case k.Type()&REAL != 0: // if real number, return numerator as interger
+		if r, ok := bigRatOf(k); ok {
+			ret = wrap(intPool.Get().(*big.Int).Set(r.Num())).(val).Integer()
 		}
 	}
 	return ret
 }
-func (b Pair) SetKey(v Evaluable) Pair {
-	return func() [2]Evaluable { return [2]Evaluable{v, b.Value()} }
-}
-func (b Pair) SetValue(v Evaluable) Pair {
-	return func() [2]Evaluable { return [2]Evaluable{b.Key(), v} }
-}
+func (b Pair) SetKey(v Evaluable) Pair   { return pairFromValues(v, b.Value()) }
+func (b Pair) SetValue(v Evaluable) Pair { return pairFromValues(b.Key(), v) }
 func (b Pair) SetBoth(k Evaluable, v Evaluable) Pair {
-	return func() [2]Evaluable { return [2]Evaluable{k, v} }
+	return pairFromValues(k, v)
 }
 func (p Pair) Serialize() []byte {
 	var delim = []byte{}
@@ -60,7 +58,25 @@ func (p Pair) Serialize() []byte {
 	)
 }
 func (b Pair) String() string  { return string(b.Serialize()) }
-func (b Pair) Type() ValueType { return TUPLE }
+func (b Pair) Type() ValueType { return PAIR }
+
+// generate pair from evaluables, the enclosed array is taken from the pool
+// and put back, when the pair gets discarded.
+func pairFromValues(k, v Evaluable) (r Pair) {
+	var p = pairPool.Get().([2]Evaluable)
+	p[0], p[1] = k, v
+	return func() [2]Evaluable { return p }
+}
 
-// generate pair from evaluables
-func pairFromValues(k, v Evaluable) (r Pair) { return r }
+// elements of pairs passed as native values keep their type. Integers become
+// Integer and byte slices Bytes, instead of the plain value, nativeToValue
+// returns for both.
+func pairElement(i interface{}) Evaluable {
+	switch i.(type) {
+	case []byte:
+		return NewBytes(i.([]byte))
+	case int, int8, int16, int32, int64:
+		return Value(i).(val).Integer()
+	}
+	return Value(i)
+}
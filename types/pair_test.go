@@ -0,0 +1,76 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+var pairTests = []struct {
+	k, v interface{}
+	typ  ValueType // expected type of the key
+	idx  int64
+	ser  string
+}{
+	{1, 2, INTEGER, 1, "1.) 2\n"},
+	{int64(-3), 2, INTEGER, -3, "-3.) 2\n"},
+	{true, 1, BOOL, 1, "\x01.) 1\n"}, // bools serialize to a single byte
+	{0.5, 3, RATIONAL, 1, "1/2.) 3\n"},
+	{[]byte("key"), []byte("value"), BYTES, -1, "key: value\n"},
+}
+
+func testPair(t *testing.T, p Pair, typ ValueType, idx int64, ser string) {
+	if p.Key().Type() != typ || p.Index().Int64() != idx || p.String() != ser {
+		(*t).Fail()
+		(*t).Log("failed pair: " + fmt.Sprintf("%q", ser) +
+			" got key type: " + p.Key().Type().String() +
+			" index: " + fmt.Sprint(p.Index().Int64()) +
+			" serialized: " + fmt.Sprintf("%q", p.String()) +
+			" expected key type: " + typ.String() +
+			" index: " + fmt.Sprint(idx))
+	} else {
+		(*t).Log("passed pair: " + fmt.Sprintf("%q", ser))
+	}
+}
+
+func TestPair(t *testing.T) {
+	for _, test := range pairTests {
+		p, ok := Value(test.k, test.v).(Pair)
+		if !ok {
+			(*t).Fatal("failed: Value(k, v) is no pair: " + fmt.Sprint(test.k, test.v))
+		}
+		testPair(t, p, test.typ, test.idx, test.ser)
+	}
+}
+
+// pairs can be nested as values and keys
+func TestPairNested(t *testing.T) {
+	var inner = Value(2, 3).(Pair)
+	var p = Value(1, inner).(Pair)
+	testPair(t, p, INTEGER, 1, "1.) 2.) 3\n\n")
+	if p.Value().Type() != PAIR || p.Value().(Pair).Value().String() != "3" {
+		(*t).Fail()
+		(*t).Log("failed: nested value got: " + fmt.Sprintf("%q", p.Value().String()))
+	}
+	testPair(t, Value(inner, 4).(Pair), PAIR, -1, "2.) 3\n: 4\n")
+}
+
+// pairs returned to the pool are reused without leaking their content
+func TestPairPool(t *testing.T) {
+	var reused = false
+	for n := 0; n < 10; n++ {
+		var p = Value(n, n+1).(Pair)
+		testPair(t, p, INTEGER, int64(n), fmt.Sprintf("%d.) %d\n", n, n+1))
+		discard(p)
+		var a = pairPool.Get().([2]Evaluable)
+		reused = reused || (a[0] != nil && Equal(a[0], NewInteger(int64(n))))
+		discardPair(a)
+	}
+	if !reused {
+		(*t).Fail()
+		(*t).Log("failed: discarded pairs never got reused")
+	}
+	var p = Value(1, 2).(Pair)
+	var q = p.SetValue(Value(5).(val).Integer())
+	testPair(t, p, INTEGER, 1, "1.) 2\n")
+	testPair(t, q, INTEGER, 1, "1.) 5\n")
+}
@@ -24,6 +24,13 @@ type ( // functional types that form the base of all value implementations
 	// simple type
 	val func() *big.Int
 
+	// floating point type
+	flt func() *big.Float
+
+	// fixed point type, mantissa and number of decimal digits after the
+	// point
+	dec func() (*big.Int, int)
+
 	// paired types
 	ratio func() *big.Rat
 	Pair  func() [2]Evaluable
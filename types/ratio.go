@@ -1,7 +1,14 @@
 package types
 
+import (
+	"math/big"
+)
+
 /////////////////////////////////////////////////////////////////////////
 // RATIONAL
+// ratios are kept normalized, with a positive denominator. All methods return
+// fresh instances and leave receiver and parameters untouched. Like big Rat,
+// a denominator of zero panics.
 type Ratio ratio
 
 func (r Ratio) Eval() Evaluable   { return r }
@@ -9,34 +16,71 @@ func (r Ratio) Serialize() []byte { return []byte(ratio(r).String()) }
 func (r Ratio) String() string    { return r().String() }
 func (r Ratio) Type() ValueType   { return RATIONAL }
 
-func (r Ratio) SetKey(v Evaluable) Ratio   { return wrap(pairPool.Get().(ratio).Ratio()).(Ratio) }
-func (r Ratio) SetValue(v Evaluable) Ratio { return wrap(pairPool.Get().(ratio).Ratio()).(Ratio) }
-func (r Ratio) SetNum(v Evaluable) Ratio   { return r.SetValue(v) }
-func (r Ratio) SetDenom(v Evaluable) Ratio { return r.SetKey(v) }
-func (r Ratio) SetFrac(a, b Evaluable) Ratio {
-	ret := wrap(pairPool.Get().(ratio).Ratio()).(Ratio)
-	ret.SetKey(a)
-	ret.SetValue(b)
-	return ret
-}
-func (r Ratio) Abs(v Ratio) Ratio {
-	return wrap(ratPool.Get().(ratio).abs(v())).(Ratio)
-}
-func (r Ratio) Add(v Ratio) Ratio {
-	return wrap(ratio(r).add(r(), v())).(Ratio)
+// numerator and denominator as integers
+func (r Ratio) Num() Integer {
+	return wrap(intPool.Get().(*big.Int).Set(r().Num())).(val).Integer()
 }
-func (r Ratio) Cmp(v Ratio) Ratio {
-	return wrap(ratio(r).cmp(v())).(Ratio)
+func (r Ratio) Denom() Integer {
+	return wrap(intPool.Get().(*big.Int).Set(r().Denom())).(val).Integer()
 }
-func (r Ratio) Neg(v Ratio) Ratio {
-	return wrap(ratio(r).neg(v())).(Ratio)
+
+// the key of a ratio is its denominator, the value its numerator. Any numeric
+// value can be passed, the result is the exact quotient.
+func (r Ratio) SetKey(v Evaluable) Ratio   { return r.SetDenom(v) }
+func (r Ratio) SetValue(v Evaluable) Ratio { return r.SetNum(v) }
+func (r Ratio) SetNum(v Evaluable) Ratio   { return r.SetFrac(v, r.Denom()) }
+func (r Ratio) SetDenom(v Evaluable) Ratio { return r.SetFrac(r.Num(), v) }
+func (r Ratio) SetFrac(a, b Evaluable) Ratio {
+	var x, okx = bigRatOf(a)
+	var y, oky = bigRatOf(b)
+	if !okx || !oky {
+		panic("types: ratio of non numeric value")
+	}
+	return wrap(newRat().Quo(x, y)).(Ratio)
 }
-func (r Ratio) Mul(v Ratio) Ratio {
-	return wrap(ratio(r).mul(r(), v())).(Ratio)
+
+func (r Ratio) Abs() Ratio  { return wrap(newRat().Abs(r())).(Ratio) }
+func (r Ratio) Neg() Ratio  { return wrap(newRat().Neg(r())).(Ratio) }
+func (r Ratio) Inv() Ratio  { return wrap(newRat().Inv(r())).(Ratio) }
+func (r Ratio) Sign() int   { return r().Sign() }
+func (r Ratio) IsInt() bool { return r().IsInt() }
+
+// Cmp returns -1, 0, or +1, if r is less than, equal to, or greater than v
+func (r Ratio) Cmp(v Ratio) int { return r().Cmp(v()) }
+
+func (r Ratio) Add(v Ratio) Ratio { return wrap(newRat().Add(r(), v())).(Ratio) }
+func (r Ratio) Sub(v Ratio) Ratio { return wrap(newRat().Sub(r(), v())).(Ratio) }
+func (r Ratio) Mul(v Ratio) Ratio { return wrap(newRat().Mul(r(), v())).(Ratio) }
+func (r Ratio) Quo(v Ratio) Ratio { return wrap(newRat().Quo(r(), v())).(Ratio) }
+
+// decimal representation, rounded to prec digits after the point
+func (r Ratio) FloatString(prec int) string { return r().FloatString(prec) }
+
+// Float rounds the ratio to a float of the passed precision, rounding to
+// nearest even. Acc of the result tells, if the conversion was exact.
+func (r Ratio) Float(prec uint) Float {
+	return wrap(newFloat(prec, big.ToNearestEven).SetRat(r())).(Float)
 }
-func (r Ratio) Quo(v Ratio) Ratio {
-	return wrap(ratio(r).quo(r(), v())).(Ratio)
+
+// NewRatio allocates the ratio of num and denom. If denom is zero, the second
+// return value is false.
+func NewRatio(num, denom Integer) (Ratio, bool) {
+	if denom().Sign() == 0 {
+		return nil, false
+	}
+	return wrap(newRat().SetFrac(num(), denom())).(Ratio), true
 }
-func (r Ratio) Sub(v Ratio) Ratio {
-	return wrap(ratio(r).sub(r(), v())).(Ratio)
+
+// ParseRatio parses fractions like "3/4", as well as decimals like "0.75", or
+// "-1.5e3". If that fails, the second return value is false.
+func ParseRatio(s string) (Ratio, bool) {
+	var r = newRat()
+	if _, ok := r.SetString(s); !ok {
+		discardRat(r)
+		return nil, false
+	}
+	return wrap(r).(Ratio), true
 }
+
+// fetches a ratio from the pool
+func newRat() *big.Rat { return ratPool.Get().(*big.Rat) }
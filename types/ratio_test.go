@@ -0,0 +1,136 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func newRatio(a, b int64) Ratio {
+	r, _ := NewRatio(NewInteger(a), NewInteger(b))
+	return r
+}
+func parseRatio(s string) Ratio {
+	r, ok := ParseRatio(s)
+	if !ok {
+		panic("can not parse ratio: " + s)
+	}
+	return r
+}
+
+// expected results are passed as fraction of numerator and denominator.
+// Integers and bools expect the numerator alone, bools are true, if it is
+// not zero.
+func ratioExpected(typ ValueType, x [2]int64) Evaluable {
+	switch typ {
+	case INTEGER:
+		return NewInteger(x[0])
+	case BOOL:
+		return Value(x[0] != 0)
+	}
+	return newRatio(x[0], x[1])
+}
+
+// function type, to be passed in the test slug
+type ratioTestFunc func(a, b Ratio) Evaluable
+
+// operands are passed as fraction of numerator and denominator
+var ratioTests = []struct {
+	a, b  [2]int64
+	typ   ValueType
+	exp   [2]int64
+	opStr string
+	op    ratioTestFunc
+}{
+	{[2]int64{3, 4}, [2]int64{1, 1}, RATIONAL, [2]int64{3, 4}, "NewRatio", func(a, b Ratio) Evaluable { return a }},
+	{[2]int64{6, -8}, [2]int64{1, 1}, RATIONAL, [2]int64{-3, 4}, "NewRatio normalized", func(a, b Ratio) Evaluable { return a }},
+	{[2]int64{1, 1}, [2]int64{1, 1}, BOOL, [2]int64{0, 1}, "NewRatio zero", func(a, b Ratio) Evaluable {
+		_, ok := NewRatio(NewInteger(1), NewInteger(0))
+		return Value(ok)
+	}},
+	{[2]int64{6, 8}, [2]int64{1, 1}, INTEGER, [2]int64{3, 1}, "Num", func(a, b Ratio) Evaluable { return a.Num() }},
+	{[2]int64{6, 8}, [2]int64{1, 1}, INTEGER, [2]int64{4, 1}, "Denom", func(a, b Ratio) Evaluable { return a.Denom() }},
+	{[2]int64{3, 4}, [2]int64{5, 1}, RATIONAL, [2]int64{5, 4}, "SetNum", func(a, b Ratio) Evaluable { return a.SetNum(b.Num()) }},
+	{[2]int64{3, 4}, [2]int64{9, 1}, RATIONAL, [2]int64{1, 3}, "SetDenom", func(a, b Ratio) Evaluable { return a.SetDenom(b.Num()) }},
+	{[2]int64{3, 4}, [2]int64{2, 10}, RATIONAL, [2]int64{1, 5}, "SetFrac", func(a, b Ratio) Evaluable {
+		return a.SetFrac(NewInteger(2), NewInteger(10))
+	}},
+	{[2]int64{1, 2}, [2]int64{3, 4}, RATIONAL, [2]int64{2, 3}, "SetFrac ratios", func(a, b Ratio) Evaluable { return a.SetFrac(a, b) }},
+	{[2]int64{3, 4}, [2]int64{2, 1}, RATIONAL, [2]int64{3, 2}, "SetKey", func(a, b Ratio) Evaluable { return a.SetKey(b.Num()) }},
+	{[2]int64{3, 4}, [2]int64{1, 1}, RATIONAL, [2]int64{1, 4}, "SetValue", func(a, b Ratio) Evaluable { return a.SetValue(b.Num()) }},
+	{[2]int64{-3, 4}, [2]int64{1, 1}, RATIONAL, [2]int64{-4, 3}, "Inv", func(a, b Ratio) Evaluable { return a.Inv() }},
+	{[2]int64{-3, 4}, [2]int64{1, 1}, RATIONAL, [2]int64{3, 4}, "Abs", func(a, b Ratio) Evaluable { return a.Abs() }},
+	{[2]int64{3, 4}, [2]int64{1, 1}, RATIONAL, [2]int64{-3, 4}, "Neg", func(a, b Ratio) Evaluable { return a.Neg() }},
+	{[2]int64{-3, 4}, [2]int64{1, 1}, INTEGER, [2]int64{-1, 1}, "Sign negative", func(a, b Ratio) Evaluable { return NewInteger(int64(a.Sign())) }},
+	{[2]int64{0, 4}, [2]int64{1, 1}, INTEGER, [2]int64{0, 1}, "Sign zero", func(a, b Ratio) Evaluable { return NewInteger(int64(a.Sign())) }},
+	{[2]int64{3, 4}, [2]int64{1, 1}, INTEGER, [2]int64{1, 1}, "Sign positive", func(a, b Ratio) Evaluable { return NewInteger(int64(a.Sign())) }},
+	{[2]int64{1, 3}, [2]int64{1, 2}, INTEGER, [2]int64{-1, 1}, "Cmp less", func(a, b Ratio) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{[2]int64{2, 4}, [2]int64{1, 2}, INTEGER, [2]int64{0, 1}, "Cmp equal", func(a, b Ratio) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{[2]int64{2, 3}, [2]int64{1, 2}, INTEGER, [2]int64{1, 1}, "Cmp greater", func(a, b Ratio) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{[2]int64{4, 2}, [2]int64{1, 1}, BOOL, [2]int64{1, 1}, "IsInt", func(a, b Ratio) Evaluable { return Value(a.IsInt()) }},
+	{[2]int64{1, 2}, [2]int64{1, 1}, BOOL, [2]int64{0, 1}, "IsInt fraction", func(a, b Ratio) Evaluable { return Value(a.IsInt()) }},
+	{[2]int64{1, 3}, [2]int64{1, 6}, RATIONAL, [2]int64{1, 2}, "Add", func(a, b Ratio) Evaluable { return a.Add(b) }},
+	{[2]int64{1, 3}, [2]int64{1, 2}, RATIONAL, [2]int64{-1, 6}, "Sub", func(a, b Ratio) Evaluable { return a.Sub(b) }},
+	{[2]int64{2, 3}, [2]int64{3, 4}, RATIONAL, [2]int64{1, 2}, "Mul", func(a, b Ratio) Evaluable { return a.Mul(b) }},
+	{[2]int64{2, 3}, [2]int64{4, 3}, RATIONAL, [2]int64{1, 2}, "Quo", func(a, b Ratio) Evaluable { return a.Quo(b) }},
+	{[2]int64{1, 3}, [2]int64{1, 3}, RATIONAL, [2]int64{1, 3}, "immutable", func(a, b Ratio) Evaluable { a.Add(b); return a }},
+}
+
+func TestRatio(t *testing.T) {
+	for n, test := range ratioTests {
+		var a, b = newRatio(test.a[0], test.a[1]), newRatio(test.b[0], test.b[1])
+		testValue(t, n, test.opStr, test.op(a, b), test.typ, ratioExpected(test.typ, test.exp))
+	}
+}
+
+// fractions, decimals and exponents parse to the exact ratio
+var ratioParseTests = []struct {
+	s   string
+	ok  bool
+	exp [2]int64
+}{
+	{"3/4", true, [2]int64{3, 4}},
+	{"0.75", true, [2]int64{3, 4}},
+	{"-1.5", true, [2]int64{-3, 2}},
+	{"2.5e-2", true, [2]int64{1, 40}},
+	{"7", true, [2]int64{7, 1}},
+	{"3/x", false, [2]int64{}},
+}
+
+func TestParseRatio(t *testing.T) {
+	for n, test := range ratioParseTests {
+		var r, ok = ParseRatio(test.s)
+		if ok != test.ok {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: parsing %q got: %v expected: %v", n, test.s, ok, test.ok))
+			continue
+		}
+		if ok {
+			testValue(t, n, "ParseRatio "+test.s, r, RATIONAL, newRatio(test.exp[0], test.exp[1]))
+		}
+	}
+}
+
+// ratios render as decimal of the passed precision
+var ratioFormatTests = []struct {
+	a    [2]int64
+	prec int
+	exp  string
+}{
+	{[2]int64{2, 3}, 4, "0.6667"},
+	{[2]int64{-5, 1}, 2, "-5.00"},
+	{[2]int64{3, 4}, -1, "3/4"}, // String
+}
+
+func TestRatioFormat(t *testing.T) {
+	for n, test := range ratioFormatTests {
+		var r = newRatio(test.a[0], test.a[1])
+		var got = r.String()
+		if test.prec >= 0 {
+			got = r.FloatString(test.prec)
+		}
+		if got != test.exp {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: FloatString(%d) got: %q expected: %q", n, test.prec, got, test.exp))
+		}
+	}
+}
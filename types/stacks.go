@@ -19,14 +19,14 @@ import (
 //////////////
 //// ITERABLE STACK ////
 // wraps the array-stack
-func (a ArrayStack) Eval() Evaluable                 { return evalCollection(a()) }
+func (a ArrayStack) Eval() Evaluable                 { return evalCollection(a) }
 func (a ArrayStack) Type() ValueType                 { return STACK }
 func (a ArrayStack) Size() int                       { return collectionSize(a()) }
 func (a ArrayStack) Empty() bool                     { return emptyCollection(a()) }
-func (a ArrayStack) Clear() Collected                { return clearCollection(a()) }
-func (a ArrayStack) Push(v Evaluable) Stacked        { return pushToStack(a, v) }
-func (a ArrayStack) Pop() (Evaluable, bool, Stacked) { return popFromStack(a) }
-func (a ArrayStack) Peek() (Evaluable, bool)         { return peekOnStack(a) }
+func (a ArrayStack) Clear() Collected                { return clearCollection(a, a()) }
+func (a ArrayStack) Push(v Evaluable) Stacked        { return pushToStack(a, a(), v) }
+func (a ArrayStack) Pop() (Evaluable, bool, Stacked) { return popFromStack(a, a()) }
+func (a ArrayStack) Peek() (Evaluable, bool)         { return peekOnStack(a()) }
 
 func (l ArrayStack) AddInterface(v ...interface{}) ArrayStack {
 	var retval = l()
@@ -48,7 +48,7 @@ func (l ArrayStack) Interfaces() []interface{} {
 }
 
 func (l ArrayStack) Values() []Evaluable {
-	return valueSlice(l.Values())
+	return valueSlice(l().Values())
 }
 func (l ArrayStack) Iter() Iterable {
 	iter := l().Iterator()
@@ -91,14 +91,14 @@ func (l ArrayStack) Serialize() []byte {
 // wraps the linked-list-stack
 // use serialization as string format base
 
-func (l LinkedStack) Eval() Evaluable                 { return evalCollection(l()) }
+func (l LinkedStack) Eval() Evaluable                 { return evalCollection(l) }
 func (l LinkedStack) Type() ValueType                 { return STACK }
 func (l LinkedStack) Size() int                       { return collectionSize(l()) }
 func (l LinkedStack) Empty() bool                     { return emptyCollection(l()) }
-func (l LinkedStack) Clear() Collected                { return clearCollection(l()) }
-func (l LinkedStack) Push(v Evaluable) Stacked        { return pushToStack(l, v) }
-func (l LinkedStack) Pop() (Evaluable, bool, Stacked) { return popFromStack(l) }
-func (l LinkedStack) Peek() (Evaluable, bool)         { return peekOnStack(l) }
+func (l LinkedStack) Clear() Collected                { return clearCollection(l, l()) }
+func (l LinkedStack) Push(v Evaluable) Stacked        { return pushToStack(l, l(), v) }
+func (l LinkedStack) Pop() (Evaluable, bool, Stacked) { return popFromStack(l, l()) }
+func (l LinkedStack) Peek() (Evaluable, bool)         { return peekOnStack(l()) }
 
 func (l LinkedStack) AddInterface(v ...interface{}) Stacked {
 	var retval = l()
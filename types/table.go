@@ -0,0 +1,269 @@
+package types
+
+//////////////////////////////////////////////////////////////////////////
+//// N-DIMENSIONAL TABLE
+///
+// a table holds its elements in row major order, so that the index of the
+// last axis varies fastest. The shape of a table is fixed at allocation, cells
+// that got no value hold an empty value. Tables are immutable, all methods
+// that alter cells, or shape, return a new version and leave the table they
+// got called on unchanged.
+type Table func() *table
+
+type table struct {
+	shape []int
+	cells []Evaluable
+}
+
+func (t Table) Eval() Evaluable           { return evalCollection(t) }
+func (t Table) Type() ValueType           { return TABLE }
+func (t Table) Size() int                 { return len(t().cells) }
+func (t Table) Empty() bool               { return len(t().cells) == 0 }
+func (t Table) Serialize() []byte         { return serializeList(t) }
+func (t Table) String() string            { return listToString(t) }
+func (t Table) Values() []Evaluable       { return append([]Evaluable{}, t().cells...) }
+func (t Table) Interfaces() []interface{} { return interfaceSlice(t.Values()) }
+
+// Clear returns a table without elements, that keeps all but the first axis
+func (t Table) Clear() Collected {
+	var shape = t.Shape()
+	shape[0] = 0
+	return tableOf(shape, []Evaluable{})
+}
+
+// Shape returns the length of each axis, starting with the outermost one
+func (t Table) Shape() []int { return append([]int{}, t().shape...) }
+func (t Table) Dim() int     { return len(t().shape) }
+
+// Get returns the element at the passed index tuple
+func (t Table) Get(idx ...int) (Evaluable, bool) {
+	var o, ok = t.offset(idx...)
+	if !ok {
+		return nil, false
+	}
+	return t().cells[o], true
+}
+
+// Set returns a version with the element at the passed index tuple replaced,
+// false if the index is out of range.
+func (t Table) Set(e Evaluable, idx ...int) (Table, bool) {
+	var o, ok = t.offset(idx...)
+	if !ok {
+		return t, false
+	}
+	var cells = t.Values()
+	cells[o] = e
+	return tableOf(t().shape, cells), true
+}
+
+// Add returns a version with the passed values appended along the first
+// axis. Values that don't fill the last slice completely, get padded by empty
+// values.
+func (t Table) Add(v ...Evaluable) Listed {
+	var block = product(t().shape[1:])
+	if len(v) == 0 || block == 0 {
+		return t
+	}
+	var rows = (len(v) + block - 1) / block
+	var cells = append(t.Values(), v...)
+	for len(cells) < len(t().cells)+rows*block {
+		cells = append(cells, emptyValue())
+	}
+	var shape = t.Shape()
+	shape[0] += rows
+	return tableOf(shape, cells)
+}
+
+// Remove returns a version without the slice at the passed index of the
+// first axis.
+func (t Table) Remove(i int) Listed {
+	if i < 0 || i >= t().shape[0] {
+		return t
+	}
+	var block = product(t().shape[1:])
+	var cells = append(append([]Evaluable{}, t().cells[:i*block]...), t().cells[(i+1)*block:]...)
+	var shape = t.Shape()
+	shape[0]--
+	return tableOf(shape, cells)
+}
+
+// Slice returns the part of the table, that ranges from the index 'from' up
+// to, but excluding the index 'to' along the passed axis. All other axes keep
+// their length.
+func (t Table) Slice(axis, from, to int) (Table, bool) {
+	if axis < 0 || axis >= t.Dim() || from < 0 || to < from || to > t().shape[axis] {
+		return t, false
+	}
+	var shape = t.Shape()
+	shape[axis] = to - from
+	var cells = make([]Evaluable, product(shape))
+	for o := range cells {
+		var idx = coordinates(shape, o)
+		idx[axis] += from
+		cells[o], _ = t.Get(idx...)
+	}
+	return tableOf(shape, cells), true
+}
+
+// Reshape returns a table of the passed shape, holding the same elements in
+// the same order. The shape needs at least one axis and has to hold the same
+// number of elements.
+func (t Table) Reshape(shape ...int) (Table, bool) {
+	if len(shape) == 0 || !validShape(shape) || product(shape) != t.Size() {
+		return t, false
+	}
+	return tableOf(append([]int{}, shape...), t().cells), true
+}
+
+// Transpose returns a table with its axes permuted, so that the i-th axis of
+// the result is the axis axes[i] of the table. Without arguments, the order
+// of the axes gets reversed.
+func (t Table) Transpose(axes ...int) (Table, bool) {
+	var dim = t.Dim()
+	if len(axes) == 0 {
+		for i := dim - 1; i >= 0; i-- {
+			axes = append(axes, i)
+		}
+	}
+	if len(axes) != dim {
+		return t, false
+	}
+	var shape = make([]int, dim)
+	var seen = make([]bool, dim)
+	for i, a := range axes {
+		if a < 0 || a >= dim || seen[a] {
+			return t, false
+		}
+		seen[a] = true
+		shape[i] = t().shape[a]
+	}
+	var cells = make([]Evaluable, len(t().cells))
+	var idx = make([]int, dim)
+	for o := range cells {
+		for i, c := range coordinates(shape, o) {
+			idx[axes[i]] = c
+		}
+		cells[o], _ = t.Get(idx...)
+	}
+	return tableOf(shape, cells), true
+}
+
+// Iterator returns an iterator over all cells in row major order
+func (t Table) Iterator() *TableIterator { return &TableIterator{t: t, cur: -1} }
+
+// position of the index tuple within the cells
+func (t Table) offset(idx ...int) (int, bool) {
+	if len(idx) != t.Dim() {
+		return 0, false
+	}
+	var o = 0
+	for i, n := range t().shape {
+		if idx[i] < 0 || idx[i] >= n {
+			return 0, false
+		}
+		o = o*n + idx[i]
+	}
+	return o, true
+}
+
+// NewTable allocates a table of the passed shape, filled by the passed values
+// in row major order. Cells beyond the passed values hold empty values. False
+// is returned, if the shape has no axis, a negative length, or is too small
+// to hold all values.
+func NewTable(shape []int, v ...Evaluable) (Table, bool) {
+	if len(shape) == 0 || !validShape(shape) || len(v) > product(shape) {
+		return nil, false
+	}
+	var cells = append(make([]Evaluable, 0, product(shape)), v...)
+	for len(cells) < cap(cells) {
+		cells = append(cells, emptyValue())
+	}
+	return tableOf(append([]int{}, shape...), cells), true
+}
+func tableOf(shape []int, cells []Evaluable) Table {
+	var t = &table{shape, cells}
+	return func() *table { return t }
+}
+
+// shape of a tabular value as list of integers
+func shapeOf(t Tabular) []Evaluable {
+	var r = []Evaluable{}
+	for _, n := range t.Shape() {
+		r = append(r, NewInteger(int64(n)))
+	}
+	return r
+}
+
+// index tuple of the offset within a row major table of the passed shape
+func coordinates(shape []int, o int) []int {
+	var r = make([]int, len(shape))
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] > 0 {
+			r[i] = o % shape[i]
+			o = o / shape[i]
+		}
+	}
+	return r
+}
+
+// number of elements, a table of the shape holds
+func product(shape []int) int {
+	var r = 1
+	for _, n := range shape {
+		r = r * n
+	}
+	return r
+}
+func validShape(shape []int) bool {
+	for _, n := range shape {
+		if n < 0 {
+			return false
+		}
+	}
+	return true
+}
+func emptyValue() Evaluable { return Empty(nil).Eval() }
+
+//////////////////////////////////////////////////////////////////////////
+//// TABLE ITERATOR
+///
+// iterates over the cells in row major order. Value returns a pair of the
+// index tuple as list of integers and the element, Index returns the position
+// of the cell in row major order.
+type TableIterator struct {
+	t   Table
+	cur int
+}
+
+func (i *TableIterator) Next() bool {
+	if i.cur < i.t.Size() {
+		i.cur++
+	}
+	return i.cur < i.t.Size()
+}
+func (i *TableIterator) Prev() bool {
+	if i.cur >= 0 {
+		i.cur--
+	}
+	return i.cur >= 0
+}
+func (i *TableIterator) Value() Evaluable {
+	if i.cur < 0 || i.cur >= i.t.Size() {
+		return nil
+	}
+	var idx = []Evaluable{}
+	for _, c := range coordinates(i.t().shape, i.cur) {
+		idx = append(idx, NewInteger(int64(c)))
+	}
+	return pairFromValues(NewVector(idx...), i.t().cells[i.cur])
+}
+func (i *TableIterator) Index() Integer {
+	if i.cur < 0 || i.cur >= i.t.Size() {
+		return NewInteger(-1)
+	}
+	return NewInteger(int64(i.cur))
+}
+func (i *TableIterator) Begin()      { i.cur = -1 }
+func (i *TableIterator) End()        { i.cur = i.t.Size() }
+func (i *TableIterator) First() bool { i.Begin(); return i.Next() }
+func (i *TableIterator) Last() bool  { i.End(); return i.Prev() }
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"testing"
+)
+
+var _ Tabular = Table(nil)
+
+// table of the passed shape, holding the integers from zero to its size
+func intTable(shape ...int) Table {
+	var v = []Evaluable{}
+	for i := 0; i < product(shape); i++ {
+		v = append(v, NewInteger(int64(i)))
+	}
+	var t, _ = NewTable(shape, v...)
+	return t
+}
+
+// expected tables are passed as shape and cells in row major order, missing
+// cells are empty
+type tableCells struct {
+	shape []int
+	v     []interface{}
+}
+
+func (c tableCells) value() Evaluable {
+	var t, _ = NewTable(c.shape, nativeValue(c.v).(ArrayList).Values()...)
+	return t
+}
+
+// function type, to be passed in the test slug
+type tableTestFunc func(t Table) Evaluable
+
+// the operand is a table of the passed shape, holding the integers from zero
+// to its size. Results of more than one value are returned as list.
+var tableTests = []struct {
+	shape []int
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    tableTestFunc
+}{
+	{[]int{2, 3}, TABLE, tableCells{[]int{2, 3}, []interface{}{0, 1, 2, 3, 4, 5}}, "NewTable", func(t Table) Evaluable { return t }},
+	{[]int{2, 2}, TABLE, tableCells{[]int{2, 2}, []interface{}{"a"}}, "NewTable padded", func(t Table) Evaluable {
+		t, _ = NewTable([]int{2, 2}, NewText("a"))
+		return t
+	}},
+	{[]int{1, 1}, LIST, []interface{}{false, false, false}, "NewTable invalid", func(t Table) Evaluable {
+		_, ok := NewTable([]int{1, 1}, NewInteger(1), NewInteger(2))
+		_, neg := NewTable([]int{-1})
+		_, none := NewTable(nil)
+		return NewArrayList(Value(ok), Value(neg), Value(none))
+	}},
+	{[]int{2, 3, 4}, INTEGER, 3, "Dim", func(t Table) Evaluable { return NewInteger(int64(t.Dim())) }},
+	{[]int{2, 3, 4}, LIST, []interface{}{23, true, 14}, "Get", func(t Table) Evaluable {
+		v, ok := t.Get(1, 2, 3)
+		w, _ := t.Get(1, 0, 2)
+		return NewArrayList(v, Value(ok), w)
+	}},
+	{[]int{2, 3}, LIST, []interface{}{false, false}, "Get out of range", func(t Table) Evaluable {
+		_, a := t.Get(2, 0)
+		_, b := t.Get(1)
+		return NewArrayList(Value(a), Value(b))
+	}},
+	{[]int{2, 2}, TABLE, tableCells{[]int{2, 2}, []interface{}{0, 1, "x", 3}}, "Set", func(t Table) Evaluable {
+		t, _ = t.Set(NewText("x"), 1, 0)
+		return t
+	}},
+	{[]int{2, 2}, TABLE, tableCells{[]int{2, 2}, []interface{}{0, 1, 2, 3}}, "Set persistent", func(t Table) Evaluable {
+		t.Set(NewText("x"), 1, 0)
+		return t
+	}},
+	{[]int{2, 2}, BOOL, false, "Set out of range", func(t Table) Evaluable { _, ok := t.Set(NewText("x"), 2, 0); return Value(ok) }},
+	{[]int{1, 3}, TABLE, tableCells{[]int{2, 3}, []interface{}{0, 1, 2, "a", "b"}}, "Add", func(t Table) Evaluable {
+		return t.Add(NewText("a"), NewText("b")).(Table)
+	}},
+	{[]int{3, 2}, TABLE, tableCells{[]int{2, 2}, []interface{}{0, 1, 4, 5}}, "Remove", func(t Table) Evaluable { return t.Remove(1).(Table) }},
+	{[]int{3, 2}, TABLE, tableCells{[]int{0, 2}, nil}, "Clear", func(t Table) Evaluable { return t.Clear().(Table) }},
+	{[]int{3, 2}, TABLE, tableCells{[]int{2, 2}, []interface{}{2, 3, 4, 5}}, "Slice rows", func(t Table) Evaluable { t, _ = t.Slice(0, 1, 3); return t }},
+	{[]int{2, 3}, TABLE, tableCells{[]int{2, 1}, []interface{}{1, 4}}, "Slice columns", func(t Table) Evaluable { t, _ = t.Slice(1, 1, 2); return t }},
+	{[]int{2, 2, 2}, TABLE, tableCells{[]int{2, 2, 1}, []interface{}{1, 3, 5, 7}}, "Slice inner axis", func(t Table) Evaluable {
+		t, _ = t.Slice(2, 1, 2)
+		return t
+	}},
+	{[]int{2, 3}, LIST, []interface{}{false, false}, "Slice invalid", func(t Table) Evaluable {
+		_, a := t.Slice(2, 0, 1)
+		_, b := t.Slice(1, 2, 4)
+		return NewArrayList(Value(a), Value(b))
+	}},
+	{[]int{2, 3}, TABLE, tableCells{[]int{3, 2}, []interface{}{0, 1, 2, 3, 4, 5}}, "Reshape", func(t Table) Evaluable { t, _ = t.Reshape(3, 2); return t }},
+	{[]int{2, 3}, BOOL, false, "Reshape invalid", func(t Table) Evaluable { _, ok := t.Reshape(4, 2); return Value(ok) }},
+	{[]int{1}, BOOL, false, "Reshape empty shape", func(t Table) Evaluable { _, ok := t.Reshape(); return Value(ok) }},
+	{[]int{2, 3}, TABLE, tableCells{[]int{3, 2}, []interface{}{0, 3, 1, 4, 2, 5}}, "Transpose", func(t Table) Evaluable { t, _ = t.Transpose(); return t }},
+	{[]int{2, 3, 4}, LIST, []interface{}{[]interface{}{3, 4, 2}, 21}, "Transpose axes", func(t Table) Evaluable {
+		t, _ = t.Transpose(1, 2, 0)
+		v, _ := t.Get(2, 1, 1)
+		return NewArrayList(NewArrayList(shapeOf(t)...), v)
+	}},
+	{[]int{2, 3, 4}, BOOL, false, "Transpose invalid", func(t Table) Evaluable { _, ok := t.Transpose(0, 0, 1); return Value(ok) }},
+	{[]int{2, 2}, LIST, []interface{}{
+		[]interface{}{[]interface{}{0, 0}, 0}, []interface{}{[]interface{}{0, 1}, 1},
+		[]interface{}{[]interface{}{1, 0}, 2}, []interface{}{[]interface{}{1, 1}, 3},
+	}, "Iterator", func(t Table) Evaluable {
+		var r = []Evaluable{}
+		for i := t.Iterator(); i.Next(); {
+			var p = i.Value().(Pair)
+			r = append(r, NewArrayList(p.Key(), p.Value()))
+		}
+		return NewArrayList(r...)
+	}},
+	{[]int{2, 2}, LIST, []interface{}{true, 3, true, 2}, "Iterator reverse", func(t Table) Evaluable {
+		var i = t.Iterator()
+		var ok = i.Last()
+		var last = i.Index()
+		return NewArrayList(Value(ok), last, Value(i.Prev()), i.Index())
+	}},
+	{[]int{2, 3}, LIST, []interface{}{false, true, false, -1}, "Equal shape", func(a Table) Evaluable {
+		var b = intTable(3, 2)
+		return NewArrayList(Value(Equal(a, b)), Value(Equal(a, intTable(2, 3))), Value(Hash(a) == Hash(b)), NewInteger(int64(Compare(a, b))))
+	}},
+}
+
+func TestTable(t *testing.T) {
+	for n, test := range tableTests {
+		testValue(t, n, test.opStr, test.op(intTable(test.shape...)), test.typ, nativeValue(test.exp))
+	}
+}
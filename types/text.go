@@ -1,7 +1,9 @@
 package types
 
 import (
-//"math/big"
+	"math/big"
+	"strings"
+	"unicode/utf8"
 )
 
 //"sync"
@@ -9,11 +11,21 @@ import (
 // STRING
 type Text val
 
+// big Int drops leading zero bytes, text is therefore stored behind a marker
+// byte, which gets stripped again on serialization.
+const textMarker byte = 1
+
 func (s Text) Eval() Evaluable { return s }
 
 // text stored in the enclosed int, is retrieved, by serializing to a  Byte
 // slice representation.
-func (s Text) Serialize() []byte { return s().Bytes() }
+func (s Text) Serialize() []byte {
+	var b = s().Bytes()
+	if len(b) > 0 {
+		return b[1:]
+	}
+	return b
+}
 
 // the string method builds a string representation og the contained data, by
 // serializing it to bytes and representing those as a string
@@ -27,15 +39,15 @@ func (s Text) SetBytes(x Bytes) Text {
 	defer discardInt(x())
 	// SetBytes takes a public Bytes instance and sets an existing text to
 	// its serialization to a byte slice
-	return Value(s().SetBytes(x.Serialize())).(val).Text()
+	return Text(wrap(s().SetBytes(markText(x.Serialize()))).(val))
 }
 
 // set a pre-existing Text Instance to a Value represented by the native byte
 // slice.
 func (s Text) SetBytesNative(x []byte) Text {
-	// setBytesNatice sets s to a native go byte slice. converted to Text
-	// via Value
-	return Value(s().SetBytes(x)).(val).Text()
+	// setBytesNatice sets s to a native go byte slice, preceded by the
+	// marker
+	return Text(wrap(s().SetBytes(markText(x))).(val))
 }
 
 // set a pre-existing Text Instance to a Value represented by the internal
@@ -43,16 +55,16 @@ func (s Text) SetBytesNative(x []byte) Text {
 func (s Text) SetText(x Text) Text {
 	// parameter instance will be reused
 	defer discardInt(x())
-	// setBytes with the string returned by the value converted to bytes as
-	// Parameter, finaly cinverted to Text via Value
-	return Value(s().SetBytes([]byte(x.String()))).(val).Text()
+	// setBytes with the serialization of the passed text as parameter,
+	// preceded by the marker
+	return Text(wrap(s().SetBytes(markText(x.Serialize()))).(val))
 }
 
 // set a pre-existing Text Instance to a Value represented by the native string.
 func (s Text) SetTextNative(x string) Text {
-	// setBytes with the string returned by the value converted to bytes as
-	// Parameter, finaly cinverted to Text via Value
-	return Value(s().SetBytes([]byte(x))).(val).Text()
+	// setBytes with the string converted to bytes as parameter, preceded
+	// by the marker
+	return Text(wrap(s().SetBytes(markText([]byte(x)))).(val))
 }
 
 // Append an Instance of the internal Bytes Type to a preexisting Text Instance
@@ -61,15 +73,14 @@ func (s Text) AppendBytes(x Bytes) Text {
 	defer discardInt(x())
 	// uses internal append funcrion and Serialize, which must be provided
 	// by all evaluable, to concatenate on a byte base
-	return Value(append(s.Serialize(), x.Serialize()...)).(val).Text()
+	return textFromBytes(append(s.Serialize(), x.Serialize()...))
 }
 
 // Append an Instance of a native byte slice to a preexisting Text Instance
 func (s Text) AppendBytesNative(x []byte) Text {
 	// uses internal append funcrion and Serialize to append a native byte
-	// Slice with a given text by re-valuabling using Value, asserting the
-	// intermediate val type and calling the Text() method on it.
-	return Value(append(s.Serialize(), x...)).(val).Text()
+	// Slice with a given text, allocating a fresh instance.
+	return textFromBytes(append(s.Serialize(), x...))
 }
 
 // Append an Instance of the internal Text Type to a preexisting Text Instance
@@ -78,7 +89,7 @@ func (s Text) AppendText(x Text) Text {
 	defer discardInt(x())
 	// uses string concatenation to append a text provided as parameter to
 	// a given Text instance
-	return Value(s.String() + x.String()).(val).Text()
+	return NewText(s.String() + x.String())
 }
 
 // Append an Instance of a native string to a preexisting Text Instance
@@ -86,5 +97,107 @@ func (s Text) AppendTextNative(x string) Text {
 	// uses gos append function and iinternal String method provided by all
 	// evaluables, to concatenate annative string  to the given Text using
 	// string concatenation.
-	return Value(s.String() + x).(val).Text()
+	return NewText(s.String() + x)
+}
+
+// NewText allocates a fresh Text instance, backed by the utf-8 bytes of the
+// passed string.
+func NewText(x string) Text { return textFromBytes([]byte(x)) }
+
+// all text instances get allocated here, to prepend the marker byte
+func textFromBytes(x []byte) Text {
+	return Text(wrap(intPool.Get().(*big.Int).SetBytes(markText(x))).(val))
+}
+func markText(x []byte) []byte {
+	var b = make([]byte, 0, len(x)+1)
+	return append(append(b, textMarker), x...)
+}
+
+// ParseText interprets a native string according to the passed mode. AS_TEXT
+// allways yields Text, AS_NUMERIC yields an Integer, if the string represents
+// a decimal number and Text otherwise.
+func ParseText(x string, m StringMode) Evaluable {
+	if m == AS_NUMERIC {
+		if i, ok := ParseInteger(x); ok {
+			return i
+		}
+	}
+	return NewText(x)
+}
+
+/////////////////////////////////////////////////////////////////////////
+//// RUNE LEVEL OPERATIONS
+///
+// all positions and lengths are counted in runes, not in bytes. Operations
+// leave receiver and parameters untouched and return fresh instances.
+
+// length of the text in runes
+func (s Text) Len() Integer {
+	return NewInteger(int64(utf8.RuneCount(s.Serialize())))
+}
+
+// Slice returns the runes from index i up to, but not including j. Indices
+// beyond the bounds of the text are clamped.
+func (s Text) Slice(i, j int) Text {
+	var r = []rune(s.String())
+	if i < 0 {
+		i = 0
+	}
+	if j > len(r) {
+		j = len(r)
+	}
+	if i >= j {
+		return NewText("")
+	}
+	return NewText(string(r[i:j]))
+}
+
+// rune index of the first occurence of x, or -1, if x is not contained
+func (s Text) Index(x Text) Integer {
+	var str = s.String()
+	var i = strings.Index(str, x.String())
+	if i > 0 {
+		i = utf8.RuneCountInString(str[:i])
+	}
+	return NewInteger(int64(i))
+}
+func (s Text) Contains(x Text) Bool {
+	return Value(strings.Contains(s.String(), x.String())).(Bool)
+}
+func (s Text) HasPrefix(x Text) Bool {
+	return Value(strings.HasPrefix(s.String(), x.String())).(Bool)
+}
+func (s Text) HasSuffix(x Text) Bool {
+	return Value(strings.HasSuffix(s.String(), x.String())).(Bool)
+}
+
+// Split returns the substrings between the occurences of sep as list of text
+func (s Text) Split(sep Text) Listed {
+	return textList(strings.Split(s.String(), sep.String()))
+}
+
+// Fields splits the text around runs of white space
+func (s Text) Fields() Listed { return textList(strings.Fields(s.String())) }
+
+func (s Text) ToUpper() Text { return NewText(strings.ToUpper(s.String())) }
+func (s Text) ToLower() Text { return NewText(strings.ToLower(s.String())) }
+
+// Trim removes all leading and trailing runes contained in cutset
+func (s Text) Trim(cutset Text) Text {
+	return NewText(strings.Trim(s.String(), cutset.String()))
+}
+func (s Text) TrimSpace() Text { return NewText(strings.TrimSpace(s.String())) }
+
+// Replace replaces the first n occurences of old by new. If n is negative,
+// all occurences get replaced.
+func (s Text) Replace(old, new Text, n int) Text {
+	return NewText(strings.Replace(s.String(), old.String(), new.String(), n))
+}
+
+func textList(s []string) Listed {
+	var v = make([]Evaluable, 0, len(s))
+	for _, str := range s {
+		v = append(v, NewText(str))
+	}
+	return NewArrayList(v...)
 }
@@ -56,4 +56,96 @@ func TestText(t *testing.T) {
 	}
 }
 
+var parseTextTests = []struct {
+	in   string
+	mode StringMode
+	typ  ValueType
+}{
+	{"hello", AS_TEXT, TEXT},
+	{"lang", AS_NUMERIC, TEXT},
+	{"42", AS_TEXT, TEXT},
+	{"42", AS_NUMERIC, INTEGER},
+	{"-17", AS_NUMERIC, INTEGER},
+	{"4.2", AS_NUMERIC, TEXT},
+	{"grüße", AS_NUMERIC, TEXT},
+}
+
+func TestParseText(t *testing.T) {
+	for n, test := range parseTextTests {
+		var v = ParseText(test.in, test.mode)
+		if v.Type() != test.typ || v.String() != test.in {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: ParseText(%q, %s) got: %s %q expected: %s",
+				n, test.in, test.mode, v.Type(), v.String(), test.typ))
+		}
+	}
+}
+
+func TestValueText(t *testing.T) {
+	if v := Value("hello"); v == nil || v.Type() != TEXT || v.String() != "hello" {
+		(*t).Fail()
+		(*t).Log(fmt.Sprintf("failed: Value(\"hello\") got: %v", v))
+	}
+	if v := Value("42"); v == nil || v.(val).Integer().Int64() != 42 {
+		(*t).Fail()
+		(*t).Log(fmt.Sprintf("failed: Value(\"42\") got: %v", v))
+	}
+	if v := NewText("a").AppendTextNative("1"); v.String() != "a1" {
+		(*t).Fail()
+		(*t).Log("failed: AppendTextNative got: " + v.String())
+	}
+	if v := NewBytes([]byte("a")).AppendTextNative("b"); v.String() != "ab" {
+		(*t).Fail()
+		(*t).Log("failed: Bytes AppendTextNative got: " + v.String())
+	}
+	if v, ok := ParseInteger("x"); ok || v != nil {
+		(*t).Fail()
+		(*t).Log("failed: ParseInteger accepted non numeric string")
+	}
+	if v := NewInteger(-5); v.Int64() != -5 {
+		(*t).Fail()
+		(*t).Log("failed: NewInteger got: " + v.String())
+	}
+}
+
+// function type, to be passed in the test slug
+type textOpTestFunc func(a Text) Evaluable
+
+var textOpTests = []struct {
+	a     string
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    textOpTestFunc
+}{
+	{"grüße", INTEGER, 5, "Len", func(a Text) Evaluable { return a.Len() }},
+	{"grüße welt", TEXT, "üße", "Slice", func(a Text) Evaluable { return a.Slice(2, 5) }},
+	{"äb", TEXT, "äb", "Slice clamped", func(a Text) Evaluable { return a.Slice(-1, 9) }},
+	{"äb", TEXT, "", "Slice empty", func(a Text) Evaluable { return a.Slice(2, 1) }},
+	{"grüße welt", INTEGER, 6, "Index", func(a Text) Evaluable { return a.Index(NewText("welt")) }},
+	{"grüße", INTEGER, -1, "Index missing", func(a Text) Evaluable { return a.Index(NewText("x")) }},
+	{"grüße", BOOL, true, "Contains", func(a Text) Evaluable { return a.Contains(NewText("üß")) }},
+	{"grüße", BOOL, true, "HasPrefix", func(a Text) Evaluable { return a.HasPrefix(NewText("gr")) }},
+	{"grüße", BOOL, false, "HasSuffix", func(a Text) Evaluable { return a.HasSuffix(NewText("gr")) }},
+	{"a,ö,c", LIST, []interface{}{"a", "ö", "c"}, "Split", func(a Text) Evaluable { return NewArrayList(a.Split(NewText(",")).Values()...) }},
+	{" a  ö\tc\n", LIST, []interface{}{"a", "ö", "c"}, "Fields", func(a Text) Evaluable { return NewArrayList(a.Fields().Values()...) }},
+	{"grüße", TEXT, "GRÜßE", "ToUpper", func(a Text) Evaluable { return a.ToUpper() }},
+	{"ÄÖÜ", TEXT, "äöü", "ToLower", func(a Text) Evaluable { return a.ToLower() }},
+	{"--ä--", TEXT, "ä", "Trim", func(a Text) Evaluable { return a.Trim(NewText("-")) }},
+	{" ä \n", TEXT, "ä", "TrimSpace", func(a Text) Evaluable { return a.TrimSpace() }},
+	{"aäaä", TEXT, "aoao", "Replace", func(a Text) Evaluable { return a.Replace(NewText("ä"), NewText("o"), -1) }},
+	{"aäaä", TEXT, "aoaä", "Replace once", func(a Text) Evaluable { return a.Replace(NewText("ä"), NewText("o"), 1) }},
+	{"\x00\x00a", TEXT, "\x00\x00a", "leading NUL", func(a Text) Evaluable { return a }},
+	{"\x00a", INTEGER, 2, "leading NUL Len", func(a Text) Evaluable { return a.Len() }},
+	{"x", TEXT, "\x00", "SetTextNative NUL", func(a Text) Evaluable { return a.SetTextNative("\x00") }},
+	{"\x00", INTEGER, 2, "AppendBytesNative", func(a Text) Evaluable { return a.AppendBytesNative([]byte("b")).Len() }},
+	{"", INTEGER, 0, "empty", func(a Text) Evaluable { return a.Len() }},
+}
+
+func TestTextOps(t *testing.T) {
+	for n, test := range textOpTests {
+		testValue(t, n, test.opStr, test.op(NewText(test.a)), test.typ, nativeValue(test.exp))
+	}
+}
+
 var L string = "Lorem ipsum dolor sit amet, consectetur adipiscing elit. Nullam convallis malesuada est eget finibus. Suspendisse suscipit tempor finibus. Cras hendrerit id lectus a pulvinar. Pellentesque a vehicula tortor, sit amet dapibus nunc. Aliquam egestas ligula lacinia odio suscipit blandit. Nulla auctor lectus dolor, a molestie lorem ultricies eget. Duis vulputate, purus et ultrices lobortis, sem tortor placerat tellus, nec venenatis enim nulla pulvinar odio. Duis lacinia faucibus mauris, at iaculis tellus dictum sed. Aliquam erat volutpat. Morbi et sollicitudin elit. Suspendisse potenti. In sed accumsan nulla, et malesuada enim. Aliquam at quam rhoncus, consequat neque et, sagittis risus. Aliquam mollis sollicitudin facilisis. Nulla sodales nibh eget viverra blandit. Pellentesque auctor porttitor consequat.\n\n Mauris et erat sed libero finibus aliquam. Proin condimentum viverra semper. Maecenas consectetur nibh id dignissim venenatis. Fusce molestie eros id mauris porta, eget semper neque vulputate. Duis tincidunt odio sed turpis facilisis euismod. Sed eget venenatis mi. Mauris efficitur orci nec ultrices aliquam. Nam lacus felis, maximus eget tincidunt sit amet, convallis eget purus. Interdum et malesuada fames ac ante ipsum primis in faucibus.\n\n Sed a imperdiet dolor, quis euismod ligula. Aenean blandit leo tortor, eget dictum libero fringilla ut. Ut a dignissim elit. Quisque elementum porta posuere. Integer rhoncus ipsum turpis, ornare egestas risus accumsan non. Quisque cursus orci ac mi auctor, in vehicula ligula accumsan. Quisque eget diam porttitor, tincidunt ex eget, iaculis leo. Nulla ultrices a neque sed feugiat. Phasellus auctor nibh eget odio tincidunt, quis commodo ipsum tempus. Suspendisse urna quam, aliquet vel risus sit amet, tempor pellentesque ligula.\n\n Nullam ac magna ac libero dapibus cursus. Phasellus vel nisl eu purus posuere aliquet. Curabitur non congue mauris, id maximus erat. Phasellus varius nisl et augue placerat, non fringilla turpis imperdiet. Mauris tellus ex, auctor vel pulvinar in, eleifend nec tortor. Vivamus sit amet nisl sit amet eros consequat pretium. In vitae leo lobortis, fringilla augue ut, facilisis eros. Donec efficitur, augue in ultrices varius, est turpis tempor enim, sed pharetra risus metus id nibh. Vivamus et sapien dictum est tempus eleifend. Nunc sed porta nibh. Duis sed felis dolor. Vestibulum sodales sagittis ex, et faucibus elit. Nam vitae felis eget neque laoreet euismod. Proin luctus efficitur lectus, non posuere dui fringilla non.\n\n Aenean quis ipsum sit amet ipsum pharetra vehicula sed porttitor erat. Integer non augue cursus erat placerat malesuada vel et libero. Aenean eu orci et augue ullamcorper malesuada. Nulla in dictum tellus. Aenean vel nisi lacus. Mauris eros lacus, mattis vel justo eu, suscipit ultricies dui. Sed porta fringilla mi vitae porttitor. Vestibulum pulvinar libero interdum eros convallis, non consequat sem placerat. Maecenas eu consequat ex. Ut mattis ut ex rutrum vestibulum.\n"
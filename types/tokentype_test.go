@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+)
+
+// function type, to be passed in the test slug
+type tokenTypeTestFunc func(a TokenType) Evaluable
+
+// one token type per renderer callback, sets of token types combine them.
+// Expected flags are passed as positions of the bits set.
+var tokenTypeTests = []struct {
+	a     TokenType
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    tokenTypeTestFunc
+}{
+	{TOKEN_NONE, TEXT, "TOKEN_NONE", "none", func(a TokenType) Evaluable { return NewText(a.String()) }},
+	{TOKEN_TABLE_CELL, TEXT, "TOKEN_TABLE_CELL", "single", func(a TokenType) Evaluable { return NewText(a.String()) }},
+	{TOKEN_HEADER, TEXT, "TOKEN_HEADER", "Serialize single", func(a TokenType) Evaluable { return NewText(string(a.Serialize())) }},
+	{TOKEN_DOCUMENT, TEXT, "TOKEN_DOCUMENT_HEADER|TOKEN_DOCUMENT_FOOTER", "Serialize set", func(a TokenType) Evaluable {
+		return NewText(string(a.Serialize()))
+	}},
+	{TokenType(0), TEXT, "TOKEN_NONE", "Serialize none", func(a TokenType) Evaluable { return NewText(string(a.Serialize())) }},
+	{TOKEN_LINK, FLAG, TOKEN_LINK, "Type", func(a TokenType) Evaluable { return a }},
+	{TOKEN_LINK | TOKEN_HEADER, LIST, []interface{}{TOKEN_HEADER, TOKEN_LINK}, "Kinds", func(a TokenType) Evaluable {
+		var r = []Evaluable{}
+		for _, k := range a.Kinds() {
+			r = append(r, k)
+		}
+		return NewArrayList(r...)
+	}},
+	{TOKEN_MASK, INTEGER, 30, "Kinds all", func(a TokenType) Evaluable { return NewInteger(int64(len(a.Kinds()))) }},
+	{TOKEN_MASK, BOOL, true, "Kinds single bit", func(a TokenType) Evaluable {
+		for _, k := range a.Kinds() {
+			if len(k.Kinds()) != 1 || k&(k-1) != 0 {
+				return Value(false)
+			}
+		}
+		return Value(true)
+	}},
+	{TOKEN_MASK, BOOL, true, "sets partition", func(a TokenType) Evaluable {
+		var all TokenType
+		for _, s := range []TokenType{TOKEN_DOCUMENT, TOKEN_BLOCK, TOKEN_SPAN, TOKEN_LOW_LEVEL} {
+			if all&s != 0 {
+				return Value(false)
+			}
+			all = all | s
+		}
+		return Value(all == a)
+	}},
+	{TOKEN_BLOCK, BOOL, true, "nested are blocks", func(a TokenType) Evaluable { return Value(a.Match(TOKEN_NESTED)) }},
+	{TOKEN_SPAN, BOOL, true, "Match", func(a TokenType) Evaluable { return Value(a.Match(TOKEN_LINK | TOKEN_IMAGE)) }},
+	{TOKEN_SPAN, BOOL, false, "Match no subset", func(a TokenType) Evaluable { return Value(a.Match(TOKEN_LINK | TOKEN_TABLE)) }},
+	{TOKEN_DOCUMENT_HEADER | TOKEN_BLOCK_CODE, FLAG, bits{1, 3}, "Flag", func(a TokenType) Evaluable { return a.Flag() }},
+	{TOKEN_SPAN, FLAG, TOKEN_SPAN, "Bool(TOKEN_TYPE)", func(a TokenType) Evaluable {
+		return a.Flag().Bool(TOKEN_TYPE).(TokenType)
+	}},
+	{TOKEN_NONE, FLAG, nil, "Bool(TOKEN_TYPE) invalid bits", func(a TokenType) Evaluable { return NewFlag(0, 6).Bool(TOKEN_TYPE) }},
+	{TOKEN_NONE, FLAG, nil, "Bool(TOKEN_TYPE) too wide", func(a TokenType) Evaluable { return NewFlag(6, 40).Bool(TOKEN_TYPE) }},
+	{TOKEN_NONE, FLAG, TOKEN_NONE, "Bool(TOKEN_TYPE) empty", func(a TokenType) Evaluable {
+		return NewFlag().Bool(TOKEN_TYPE).(TokenType)
+	}},
+}
+
+func TestTokenType(t *testing.T) {
+	for n, test := range tokenTypeTests {
+		testValue(t, n, test.opStr, test.op(test.a), test.typ, nativeValue(test.exp))
+	}
+}
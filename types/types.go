@@ -9,7 +9,7 @@ For byte slices, strings, booleans, bit-flags, integers, and unsigned integers,
 the underlying base type is math/big Int. That type comes with all the
 nescesssary methods to act as all those types, as well as store and manipulate
 the values they encode in a higly effective manner. Ratios and Floats are
-represented by math/big.Rat in arbitrary praesition. A two field struct is the
+represented by math/big.Rat and math/big.Float in arbitrary praesition. A two field struct is the
 base for all tuples, key/value, value/index combinations and whatever else
 comes in pairs, since you should take two of each kind and build a boat, or
 something… All kinds of collections are implemented in container types taken
@@ -108,14 +108,19 @@ func init() {
 // reuse
 func discard(v Evaluable) {
 	switch { //…discard each in appropriate pool
-	case v.Type()&NATURAL != 0:
-		discardInt(v.(val)())
-	case v.Type()&REAL != 0:
-		discardRat(v.(Ratio)())
-	case v.Type()&FLOAT != 0:
-		discardRat(v.(Float)())
-	case v.Type()&TUPLE != 0:
+	case v.Type()&PAIR != 0:
 		discardPair(v.(Pair)())
+	case v.Type()&FLOAT != 0:
+		discardFloat(v.(Float)())
+	case v.Type()&RATIONAL != 0:
+		discardRat(v.(Ratio)())
+	case v.Type()&DECIMAL != 0:
+		var m, _ = v.(Decimal)()
+		discardInt(m)
+	default:
+		if i, ok := bigIntOf(v); ok {
+			discardInt(i)
+		}
 	}
 }
 
@@ -152,10 +157,12 @@ func wrap(i interface{}) (r Evaluable) {
 	case *big.Rat:
 		r = Ratio(func() *big.Rat { return i.(*big.Rat) })
 	case *big.Float:
-		r = Float(func() *big.Rat { return i.(*big.Rat) })
+		r = Float(func() *big.Float { return i.(*big.Float) })
 	case Pair:
-		// set key to zero and value to passed interface
-		r = wrap([2]Evaluable{i.(Pair).Key(), i.(Pair).Value()})
+		// enclose key and value in a fresh pair
+		r = pairFromValues(i.(Pair).Key(), i.(Pair).Value())
+	case [2]Evaluable:
+		r = pairFromValues(i.([2]Evaluable)[0], i.([2]Evaluable)[1])
 	}
 	return r
 }
@@ -225,7 +232,7 @@ func (v val) xor(x, y *big.Int) *big.Int                    { return v().Xor(x,
 func (v val) Integer() Integer { return Integer(v) }
 func (v val) Bool() Bool       { return Bool(v) }
 func (v val) Bytes() Bytes     { return Bytes(v) }
-func (v val) Text() Text       { return Text(v) }
+func (v val) Text() Text       { return textFromBytes(v().Bytes()) }
 
 /////////////////////////////////////////////////
 ////// VAL METHODS TO IMPLEMENT EVALUABLE ///////
@@ -252,7 +259,7 @@ func (b val) Uint64() uint64   { return b().Uint64() }
 func (b val) toBitFlag() BitFlag { return BitFlag(b) }
 func (b val) toFlag() Bool       { return Bool(b) }
 func (b val) toInteger() Integer { return Integer(b) }
-func (b val) toText() Text       { return Text(b) }
+func (b val) toText() Text       { return b.Text() }
 
 // assign receiver value as returnvalue, set key to zero
 func (b val) toPair() Pair {
@@ -299,7 +306,7 @@ func (r ratio) string() string                        { return r().String() }
 func (r ratio) sub(x, y *big.Rat) *big.Rat            { return r().Sub(x, y) }
 func (r ratio) unmarshalText(text []byte) error       { return r().UnmarshalText(text) }
 
-func (r ratio) Float() Float { return Float(r) }
+func (r ratio) Float() Float { return Ratio(r).Float(DefaultPrec) }
 func (r ratio) Ratio() Ratio { return Ratio(r) }
 
 /////////////////////////////////////////////////
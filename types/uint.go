@@ -0,0 +1,108 @@
+package types
+
+import (
+	"math/big"
+)
+
+/////////////////////////////////////////////////////////////////////////
+// UNSIGNED INTEGER
+// unsigned integers are unbounded and never wrap around. Operations that
+// would yield a negative result, return false as second value instead.
+type Uint val
+
+func (u Uint) Eval() Evaluable   { return u }
+func (u Uint) Serialize() []byte { return []byte(u.String()) }
+func (u Uint) String() string    { return val(u).text(10) }
+func (u Uint) Type() ValueType   { return UINT }
+
+// native value, the second return value is false, if it overflows uint64
+func (u Uint) Uint64() (uint64, bool) { return u().Uint64(), u().IsUint64() }
+
+func (u Uint) Cmp(y Uint) int { return u().Cmp(y()) }
+func (u Uint) Add(y Uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Add(u(), y())).(val))
+}
+func (u Uint) Sub(y Uint) (Uint, bool) { return uintOf(intPool.Get().(*big.Int).Sub(u(), y())) }
+func (u Uint) Mul(y Uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Mul(u(), y())).(val))
+}
+
+// truncated quotient and remainder. A divisor of zero returns false.
+func (u Uint) Quo(y Uint) (Uint, bool) {
+	if y().Sign() == 0 {
+		return nil, false
+	}
+	return Uint(wrap(intPool.Get().(*big.Int).Quo(u(), y())).(val)), true
+}
+func (u Uint) Rem(y Uint) (Uint, bool) {
+	if y().Sign() == 0 {
+		return nil, false
+	}
+	return Uint(wrap(intPool.Get().(*big.Int).Rem(u(), y())).(val)), true
+}
+
+//// BITWISE
+func (u Uint) And(y Uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).And(u(), y())).(val))
+}
+func (u Uint) AndNot(y Uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).AndNot(u(), y())).(val))
+}
+func (u Uint) Or(y Uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Or(u(), y())).(val))
+}
+func (u Uint) Xor(y Uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Xor(u(), y())).(val))
+}
+func (u Uint) Lsh(n uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Lsh(u(), n)).(val))
+}
+func (u Uint) Rsh(n uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Rsh(u(), n)).(val))
+}
+func (u Uint) Bit(i int) uint { return u().Bit(i) }
+func (u Uint) BitLen() int    { return u().BitLen() }
+func (u Uint) SetBit(i int, b uint) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).SetBit(u(), i, b&1)).(val))
+}
+
+//// CONVERSIONS
+func (u Uint) Integer() Integer {
+	return wrap(intPool.Get().(*big.Int).Set(u())).(val).Integer()
+}
+
+// zero is false, all other values are true
+func (u Uint) Bool() Bool { return Value(u().Sign() != 0).(Bool) }
+
+// each set bit of the value is a set flag
+func (u Uint) BitFlag() BitFlag {
+	return wrap(intPool.Get().(*big.Int).Set(u())).(val).bitFlag()
+}
+
+// negative integers have no unsigned representation
+func (i Integer) Uint() (Uint, bool) { return uintOf(intPool.Get().(*big.Int).Set(i())) }
+
+// true is one, false zero
+func (u Bool) Uint() Uint {
+	if u.Native() {
+		return NewUint(1)
+	}
+	return NewUint(0)
+}
+func (f BitFlag) Uint() Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).Set(f())).(val))
+}
+
+// NewUint allocates an unsigned integer from a native uint64
+func NewUint(x uint64) Uint {
+	return Uint(wrap(intPool.Get().(*big.Int).SetUint64(x)).(val))
+}
+
+// wraps the int as Uint, unless it's negative
+func uintOf(i *big.Int) (Uint, bool) {
+	if i.Sign() < 0 {
+		discardInt(i)
+		return nil, false
+	}
+	return Uint(wrap(i).(val)), true
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+)
+
+// expected results are passed as int64 and converted to the expected type.
+// Bools are true, if the expected value is not zero, flags have the bits of
+// the value set.
+func uintExpected(typ ValueType, x int64) Evaluable {
+	switch typ {
+	case INTEGER:
+		return NewInteger(x)
+	case BOOL:
+		return Value(x != 0)
+	case FLAG:
+		return NewUint(uint64(x)).BitFlag()
+	}
+	return NewUint(uint64(x))
+}
+
+// function type, to be passed in the test slug
+type uintTestFunc func(a, b Uint) Evaluable
+
+var uintTests = []struct {
+	a, b  uint64
+	typ   ValueType
+	exp   int64
+	opStr string
+	op    uintTestFunc
+}{
+	{2, 3, UINT, 5, "Add", func(a, b Uint) Evaluable { return a.Add(b) }},
+	{1<<64 - 1, 1, INTEGER, 65, "Add overflow", func(a, b Uint) Evaluable { return NewInteger(int64(a.Add(b).BitLen())) }},
+	{1<<64 - 1, 1, BOOL, 0, "Uint64 overflow", func(a, b Uint) Evaluable { _, ok := a.Add(b).Uint64(); return Value(ok) }},
+	{5, 3, UINT, 2, "Sub", func(a, b Uint) Evaluable { r, _ := a.Sub(b); return r }},
+	{3, 3, UINT, 0, "Sub zero", func(a, b Uint) Evaluable { r, _ := a.Sub(b); return r }},
+	{3, 5, BOOL, 0, "Sub negative", func(a, b Uint) Evaluable { _, ok := a.Sub(b); return Value(ok) }},
+	{6, 7, UINT, 42, "Mul", func(a, b Uint) Evaluable { return a.Mul(b) }},
+	{7, 2, UINT, 3, "Quo", func(a, b Uint) Evaluable { r, _ := a.Quo(b); return r }},
+	{7, 0, BOOL, 0, "Quo zero", func(a, b Uint) Evaluable { _, ok := a.Quo(b); return Value(ok) }},
+	{7, 4, UINT, 3, "Rem", func(a, b Uint) Evaluable { r, _ := a.Rem(b); return r }},
+	{1, 2, INTEGER, -1, "Cmp", func(a, b Uint) Evaluable { return NewInteger(int64(a.Cmp(b))) }},
+	{12, 10, UINT, 8, "And", func(a, b Uint) Evaluable { return a.And(b) }},
+	{12, 10, UINT, 4, "AndNot", func(a, b Uint) Evaluable { return a.AndNot(b) }},
+	{12, 10, UINT, 14, "Or", func(a, b Uint) Evaluable { return a.Or(b) }},
+	{12, 10, UINT, 6, "Xor", func(a, b Uint) Evaluable { return a.Xor(b) }},
+	{1, 70, INTEGER, 71, "Lsh", func(a, b Uint) Evaluable { return NewInteger(int64(a.Lsh(70).BitLen())) }},
+	{1, 70, UINT, 4, "Lsh Rsh", func(a, b Uint) Evaluable { return a.Lsh(70).Rsh(68) }},
+	{12, 2, UINT, 3, "Rsh", func(a, b Uint) Evaluable { return a.Rsh(2) }},
+	{4, 2, UINT, 1, "Bit", func(a, b Uint) Evaluable { return NewUint(uint64(a.Bit(2))) }},
+	{4, 1, UINT, 0, "Bit unset", func(a, b Uint) Evaluable { return NewUint(uint64(a.Bit(1))) }},
+	{4, 0, UINT, 1, "SetBit", func(a, b Uint) Evaluable { return a.SetBit(0, 1).SetBit(2, 0) }},
+	{255, 0, INTEGER, 8, "BitLen", func(a, b Uint) Evaluable { return NewInteger(int64(a.BitLen())) }},
+	{9, 0, INTEGER, 9, "Integer", func(a, b Uint) Evaluable { return a.Integer() }},
+	{9, 0, UINT, 9, "from Integer", func(a, b Uint) Evaluable { r, _ := NewInteger(9).Uint(); return r }},
+	{9, 0, BOOL, 0, "from negative", func(a, b Uint) Evaluable { _, ok := NewInteger(-9).Uint(); return Value(ok) }},
+	{2, 0, BOOL, 1, "Bool", func(a, b Uint) Evaluable { return a.Bool() }},
+	{0, 0, BOOL, 0, "Bool zero", func(a, b Uint) Evaluable { return a.Bool() }},
+	{0, 0, UINT, 1, "from Bool", func(a, b Uint) Evaluable { return Value(true).(Bool).Uint() }},
+	{0, 0, UINT, 0, "from Bool false", func(a, b Uint) Evaluable { return Value(false).(Bool).Uint() }},
+	{5, 0, FLAG, 5, "BitFlag", func(a, b Uint) Evaluable { return a.BitFlag() }},
+	{5, 0, UINT, 5, "from BitFlag", func(a, b Uint) Evaluable { return a.BitFlag().Uint() }},
+	{2, 5, INTEGER, -3, "Add dispatch", func(a, b Uint) Evaluable { r, _ := Add(a, NewInteger(-5)); return r }},
+	{2, 0, UINT, 2, "pair key", func(a, b Uint) Evaluable { return Value(uint(2), "x").(Pair).Key() }},
+}
+
+func TestUint(t *testing.T) {
+	for n, test := range uintTests {
+		testValue(t, n, test.opStr, test.op(NewUint(test.a), NewUint(test.b)), test.typ, uintExpected(test.typ, test.exp))
+	}
+}
+
+// all unsigned native integers become Uint
+var uintValueTests = []struct {
+	v   interface{}
+	exp uint64
+}{
+	{uint8(200), 200},
+	{byte('a'), 97},
+	{uint16(1 << 15), 32768},
+	{uint32(1 << 31), 2147483648},
+	{uint(7), 7},
+	{uintptr(8), 8},
+	{uint64(1<<64 - 1), 1<<64 - 1},
+}
+
+func TestUintValue(t *testing.T) {
+	for n, test := range uintValueTests {
+		testValue(t, n, "Value", Value(test.v), UINT, NewUint(test.exp))
+	}
+}
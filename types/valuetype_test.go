@@ -0,0 +1,89 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+// value types split into their kinds and serialize to their names
+var valueTypeTests = []struct {
+	v     ValueType
+	kinds []ValueType
+	exp   string // serialization
+}{
+	{EMPTY, []ValueType{}, "EMPTY"},
+	{INTEGER, []ValueType{INTEGER}, "INTEGER"},
+	{DECIMAL, []ValueType{DECIMAL}, "DECIMAL"},
+	{NODE, []ValueType{NODE}, "NODE"},
+	{ERROR, []ValueType{ERROR}, "ERROR"},
+	{NODE | LIST, []ValueType{LIST, NODE}, "LIST|NODE"},
+	{TEXT | ERROR, []ValueType{TEXT, ERROR}, "TEXT|ERROR"},
+}
+
+// every single bit of the mask is a kind of its own
+func TestValueTypeMask(t *testing.T) {
+	var kinds = ValueType(MASK).Kinds()
+	if len(kinds) == 0 || kinds[len(kinds)-1] != ERROR {
+		(*t).Fail()
+		(*t).Log("failed: highest kind of the mask got: " + fmt.Sprint(kinds) + " expected: ERROR")
+	}
+}
+
+func TestValueType(t *testing.T) {
+	for n, test := range valueTypeTests {
+		var kinds = test.v.Kinds()
+		var ok = len(kinds) == len(test.kinds)
+		for i := 0; ok && i < len(kinds); i++ {
+			ok = kinds[i] == test.kinds[i]
+		}
+		if !ok || string(test.v.Serialize()) != test.exp {
+			(*t).Fail()
+			(*t).Log(fmt.Sprintf("failed Test Nr. %d: %d got kinds: %v serialized: %q expected kinds: %v serialized: %q",
+				n, test.v, kinds, test.v.Serialize(), test.kinds, test.exp))
+		}
+	}
+}
+
+// testValue runs the actual check of table driven tests. The result needs to
+// be of the expected value type and equal to the expected value. Expecting
+// nil, the result needs to be nil as well.
+// fails, unless the result is of the expected type and equals the expected
+// value
+func testValue(t *testing.T, n int, opStr string, res Evaluable, typ ValueType, exp Evaluable) {
+	if Equal(res, exp) && (res == nil || res.Type() == typ) {
+		return
+	}
+	var got = "nil"
+	if res != nil {
+		got = res.Type().String() + " " + fmt.Sprintf("%q", res.String())
+	}
+	(*t).Fail()
+	(*t).Log(fmt.Sprintf("failed Test Nr. %d: %s got: %s expected: %s %q",
+		n, opStr, got, typ, fmt.Sprint(exp)))
+}
+
+// expected values of types without native representation
+type expectation interface {
+	value() Evaluable
+}
+
+// expected values are passed as natives and converted to evaluables.
+// Strings are text, even if numeric, slices become lists of their converted
+// elements.
+func nativeValue(x interface{}) Evaluable {
+	switch y := x.(type) {
+	case nil:
+		return nil
+	case expectation:
+		return y.value()
+	case string:
+		return NewText(y)
+	case []interface{}:
+		var l = []Evaluable{}
+		for _, e := range y {
+			l = append(l, nativeValue(e))
+		}
+		return NewArrayList(l...)
+	}
+	return Value(x)
+}
@@ -0,0 +1,196 @@
+package types
+
+//////////////////////////////////////////////////////////////////////////
+//// PERSISTENT VECTOR
+///
+// a vector is an immutable list, implemented as bit partitioned trie of 32
+// way branching nodes. Updates copy the path from the root to the changed
+// leaf only and share all other nodes with the previous version, so that
+// appending, setting and removing the last element take O(log n) time. Every
+// version stays valid and unchanged, after new ones got derived from it.
+type Vector func() *vectorTrie
+
+const (
+	trieBits  = 5
+	trieWidth = 1 << trieBits
+	trieMask  = trieWidth - 1
+)
+
+type vectorTrie struct {
+	size  int
+	shift uint // number of bits to shift the index by at the root level
+	root  *trieNode
+}
+
+// branch nodes reference their children, leaf nodes hold the values
+type trieNode struct {
+	nodes  []*trieNode
+	values []Evaluable
+}
+
+func (v Vector) Eval() Evaluable     { return evalCollection(v) }
+func (v Vector) Type() ValueType     { return LIST }
+func (v Vector) Size() int           { return v().size }
+func (v Vector) Empty() bool         { return v().size == 0 }
+func (v Vector) Clear() Collected    { return NewVector() }
+func (v Vector) Serialize() []byte   { return serializeList(v) }
+func (v Vector) String() string      { return listToString(v) }
+func (v Vector) Values() []Evaluable { return v().root.collect([]Evaluable{}) }
+func (v Vector) Interfaces() []interface{} {
+	return interfaceSlice(v.Values())
+}
+
+// Get returns the element at the passed index
+func (v Vector) Get(i int) (Evaluable, bool) {
+	if i < 0 || i >= v().size {
+		return nil, false
+	}
+	var n = v().root
+	for level := v().shift; level > 0; level -= trieBits {
+		n = n.nodes[(i>>level)&trieMask]
+	}
+	return n.values[i&trieMask], true
+}
+
+// Set returns a version with the element at the passed index replaced, false
+// if the index is out of range.
+func (v Vector) Set(i int, e Evaluable) (Vector, bool) {
+	if i < 0 || i >= v().size {
+		return v, false
+	}
+	var t = *v()
+	t.root = t.root.set(t.shift, i, e)
+	return vectorOf(&t), true
+}
+
+// Add returns a version with the passed values appended
+func (v Vector) Add(e ...Evaluable) Listed {
+	var t = *v()
+	for _, e := range e {
+		if t.size == 1<<(t.shift+trieBits) { // root is full, grow a level
+			t.root = &trieNode{nodes: []*trieNode{t.root}}
+			t.shift += trieBits
+		}
+		t.root = t.root.push(t.shift, t.size, e)
+		t.size++
+	}
+	return vectorOf(&t)
+}
+
+// Remove returns a version without the element at the passed index. Removing
+// the last element takes O(log n), all other elements O(n), since all
+// following elements have to move.
+func (v Vector) Remove(i int) Listed {
+	switch {
+	case i < 0 || i >= v().size:
+		return v
+	case i == v().size-1:
+		return v.pop()
+	}
+	var e = v.Values()
+	return NewVector(append(e[:i:i], e[i+1:]...)...)
+}
+func (v Vector) pop() Vector {
+	var t = *v()
+	t.root = t.root.pop(t.shift, t.size-1)
+	t.size--
+	if t.root != nil && t.shift > 0 && len(t.root.nodes) == 1 {
+		t.root = t.root.nodes[0]
+		t.shift -= trieBits
+	}
+	return vectorOf(&t)
+}
+
+// Contains determines if all passed values are elements of the vector
+func (v Vector) Contains(e ...Evaluable) bool {
+	var buckets = hashBuckets(v.Values())
+	for _, e := range e {
+		if !containsEqual(buckets[Hash(e)], e) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewVector allocates a vector containing the passed values
+func NewVector(v ...Evaluable) Vector {
+	return vectorOf(&vectorTrie{}).Add(v...).(Vector)
+}
+func vectorOf(t *vectorTrie) Vector { return func() *vectorTrie { return t } }
+
+// copy of the node, nil yields a new one
+func (n *trieNode) clone() *trieNode {
+	if n == nil {
+		return &trieNode{}
+	}
+	var r = &trieNode{}
+	if n.nodes != nil {
+		r.nodes = append(make([]*trieNode, 0, len(n.nodes)+1), n.nodes...)
+	}
+	if n.values != nil {
+		r.values = append(make([]Evaluable, 0, len(n.values)+1), n.values...)
+	}
+	return r
+}
+
+// appends the value at index i, copying the path to its leaf
+func (n *trieNode) push(level uint, i int, e Evaluable) *trieNode {
+	var r = n.clone()
+	if level == 0 {
+		r.values = append(r.values, e)
+		return r
+	}
+	var idx = (i >> level) & trieMask
+	if idx < len(r.nodes) {
+		r.nodes[idx] = r.nodes[idx].push(level-trieBits, i, e)
+	} else {
+		r.nodes = append(r.nodes, (*trieNode)(nil).push(level-trieBits, i, e))
+	}
+	return r
+}
+
+// replaces the value at index i, copying the path to its leaf
+func (n *trieNode) set(level uint, i int, e Evaluable) *trieNode {
+	var r = n.clone()
+	if level == 0 {
+		r.values[i&trieMask] = e
+		return r
+	}
+	var idx = (i >> level) & trieMask
+	r.nodes[idx] = r.nodes[idx].set(level-trieBits, i, e)
+	return r
+}
+
+// removes the last value at index i, nodes left empty are removed as well
+func (n *trieNode) pop(level uint, i int) *trieNode {
+	var r = n.clone()
+	if level == 0 {
+		r.values = r.values[:len(r.values)-1]
+		if len(r.values) == 0 {
+			return nil
+		}
+		return r
+	}
+	var idx = (i >> level) & trieMask
+	if c := r.nodes[idx].pop(level-trieBits, i); c != nil {
+		r.nodes[idx] = c
+		return r
+	}
+	r.nodes = r.nodes[:idx]
+	if len(r.nodes) == 0 {
+		return nil
+	}
+	return r
+}
+
+// appends all values in order
+func (n *trieNode) collect(r []Evaluable) []Evaluable {
+	if n == nil {
+		return r
+	}
+	r = append(r, n.values...)
+	for _, c := range n.nodes {
+		r = c.collect(r)
+	}
+	return r
+}
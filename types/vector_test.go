@@ -0,0 +1,129 @@
+package types
+
+import (
+	"testing"
+)
+
+var _ Listed = Vector(nil)
+
+// vector of the integers from zero to n-1
+func intVector(n int) Vector {
+	var v = NewVector()
+	for i := 0; i < n; i++ {
+		v = v.Add(NewInteger(int64(i))).(Vector)
+	}
+	return v
+}
+
+// determines if the vector holds the integers from zero to its size in order
+func countsUp(v Vector) bool {
+	for i, e := range v.Values() {
+		if g, ok := v.Get(i); !ok || !Equal(g, e) || !Equal(e, NewInteger(int64(i))) {
+			return false
+		}
+	}
+	return true
+}
+
+// function type, to be passed in the test slug
+type vectorTestFunc func(v Vector) Evaluable
+
+// the operand holds the integers from zero to n-1. Results of more than one
+// value are returned as list.
+var vectorTests = []struct {
+	n     int
+	typ   ValueType
+	exp   interface{}
+	opStr string
+	op    vectorTestFunc
+}{
+	{0, LIST, []interface{}{1, "a"}, "NewVector", func(v Vector) Evaluable { return NewVector(NewInteger(1), NewText("a")) }},
+	{0, BOOL, true, "Empty", func(v Vector) Evaluable { return Value(v.Empty()) }},
+	{1, BOOL, false, "Empty set", func(v Vector) Evaluable { return Value(v.Empty()) }},
+	{10, LIST, []interface{}{7, true}, "Get", func(v Vector) Evaluable { e, ok := v.Get(7); return NewArrayList(e, Value(ok)) }},
+	{10, BOOL, false, "Get out of range", func(v Vector) Evaluable { _, ok := v.Get(10); return Value(ok) }},
+	{5000, LIST, []interface{}{5000, true}, "Add deep", func(v Vector) Evaluable {
+		return NewArrayList(NewInteger(int64(v.Size())), Value(countsUp(v)))
+	}},
+	{40, LIST, []interface{}{40, 41, true, true}, "Add persistent", func(a Vector) Evaluable {
+		var b = a.Add(NewInteger(40)).(Vector)
+		return NewArrayList(NewInteger(int64(a.Size())), NewInteger(int64(b.Size())), Value(countsUp(a)), Value(countsUp(b)))
+	}},
+	{100, LIST, []interface{}{70, "x", true}, "Set", func(a Vector) Evaluable {
+		var b, ok = a.Set(70, NewText("x"))
+		x, _ := a.Get(70)
+		y, _ := b.Get(70)
+		return NewArrayList(x, y, Value(ok))
+	}},
+	{3, BOOL, false, "Set out of range", func(v Vector) Evaluable { _, ok := v.Set(3, NewText("x")); return Value(ok) }},
+	{33, LIST, []interface{}{33, 32, true, 0}, "Remove last", func(a Vector) Evaluable {
+		var b = a.Remove(32).(Vector)
+		return NewArrayList(NewInteger(int64(a.Size())), NewInteger(int64(b.Size())), Value(countsUp(b)), NewInteger(int64(b().shift)))
+	}},
+	{1100, LIST, []interface{}{0, true}, "Remove all", func(v Vector) Evaluable {
+		for !v.Empty() {
+			v = v.Remove(v.Size() - 1).(Vector)
+			if !countsUp(v) {
+				return v
+			}
+		}
+		return NewArrayList(NewInteger(int64(v.Size())), Value(v().root == nil))
+	}},
+	{5, LIST, []interface{}{[]interface{}{0, 2, 3, 4}, []interface{}{0, 1, 2, 3, 4}}, "Remove middle", func(a Vector) Evaluable {
+		return NewArrayList(a.Remove(1).(Vector), a)
+	}},
+	{0, LIST, []interface{}{true, false}, "Contains", func(v Vector) Evaluable {
+		v = NewVector(NewText("a"), NewArrayList(NewInteger(1)))
+		return NewArrayList(Value(v.Contains(NewArrayList(NewInteger(1)))), Value(v.Contains(NewText("b"))))
+	}},
+	{3, BOOL, true, "Clear", func(v Vector) Evaluable { return Value(v.Clear().Empty()) }},
+	{3, LIST, []interface{}{0, 1, 2}, "Equal ArrayList", func(v Vector) Evaluable { return v }},
+}
+
+func TestVector(t *testing.T) {
+	for n, test := range vectorTests {
+		testValue(t, n, test.opStr, test.op(intVector(test.n)), test.typ, nativeValue(test.exp))
+	}
+}
+
+//// BENCHMARKS
+// persistent vectors compared to the array lists of gods
+func BenchmarkVectorAdd(b *testing.B) {
+	var v = NewVector()
+	var e = NewInteger(1)
+	for i := 0; i < b.N; i++ {
+		v = v.Add(e).(Vector)
+	}
+}
+func BenchmarkArrayListAdd(b *testing.B) {
+	var l = NewArrayList()
+	var e = NewInteger(1)
+	for i := 0; i < b.N; i++ {
+		l.Add(e)
+	}
+}
+func BenchmarkVectorGet(b *testing.B) {
+	var v = intVector(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Get(i % 10000)
+	}
+}
+func BenchmarkArrayListGet(b *testing.B) {
+	var l = NewArrayList()
+	for i := 0; i < 10000; i++ {
+		l.Add(NewInteger(int64(i)))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Get(i % 10000)
+	}
+}
+func BenchmarkVectorSet(b *testing.B) {
+	var v = intVector(10000)
+	var e = NewInteger(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v, _ = v.Set(i%10000, e)
+	}
+}